@@ -19,6 +19,19 @@ data "qdrant-cloud_accounts_cluster" "specific_cluster" {
   id = "00000000-0000-0000-0000-000000000000" // Update with the ID to fetch
 }
 
+// Look up a cluster by name (and/or labels), which should match a single cluster
+data "qdrant-cloud_accounts_cluster" "named_cluster" {
+  name = "prod-search" // Update with the name of the cluster to fetch
+  labels {
+    key   = "env"
+    value = "prod"
+  }
+}
+
 output "cluster" {
   value = data.qdrant-cloud_accounts_cluster.specific_cluster
+}
+
+output "named_cluster_url" {
+  value = data.qdrant-cloud_accounts_cluster.named_cluster.url
 }
\ No newline at end of file
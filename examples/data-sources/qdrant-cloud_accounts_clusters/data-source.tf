@@ -22,4 +22,21 @@ data "qdrant-cloud_accounts_clusters" "all_clusters" {
 
 output "clusters" {
   value = data.qdrant-cloud_accounts_clusters.all_clusters.clusters
+}
+
+// List the healthy production clusters of the search and recommend teams running Qdrant v1.13.x
+data "qdrant-cloud_accounts_clusters" "prod_clusters" {
+  label_selector = "env=prod,team in (search,recommend)"
+  name_regex     = "^prod-"
+  cloud_provider = "aws"
+  phase          = "CLUSTER_PHASE_HEALTHY"
+  version        = "v1.13"
+}
+
+output "prod_cluster_ids" {
+  value = data.qdrant-cloud_accounts_clusters.prod_clusters.ids
+}
+
+output "prod_cluster_names" {
+  value = data.qdrant-cloud_accounts_clusters.prod_clusters.names
 }
\ No newline at end of file
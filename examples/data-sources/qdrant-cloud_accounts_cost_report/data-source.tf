@@ -0,0 +1,27 @@
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.13.0"
+    }
+  }
+}
+
+provider "qdrant-cloud" {
+  api_key    = "" # API Key generated in Qdrant Cloud (required)
+  account_id = "" # Default account ID (can be overridden per data source)
+}
+
+# Estimate the cost of all clusters in the account
+data "qdrant-cloud_accounts_cost_report" "all" {}
+
+# Output the estimated monthly cost per currency
+output "monthly_cost" {
+  value = { for total in data.qdrant-cloud_accounts_cost_report.all.totals : total.currency => total.monthly_cost }
+}
+
+# Output the estimated monthly cost per cluster
+output "cluster_monthly_cost" {
+  value = { for cluster in data.qdrant-cloud_accounts_cost_report.all.clusters : cluster.name => cluster.monthly_cost if cluster.estimated }
+}
@@ -0,0 +1,34 @@
+// Setup Terraform, including the qdrant-cloud providers
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.1.0"
+    }
+  }
+}
+
+// Add the provider to specify some provider wide settings
+provider "qdrant-cloud" {
+  api_key    = "" // API Key generated in Qdrant Cloud (required)
+  account_id = "" // The default account ID you want to use in Qdrant Cloud (can be overriden on resource level)
+}
+
+data "qdrant-cloud_accounts_hybrid_cloud_environment" "specific_env" {
+  id = "00000000-0000-0000-0000-000000000000" // Update with the ID to fetch
+}
+
+// Look up a hybrid cloud environment by name (e.g. owned by another workspace), which should match a single environment
+data "qdrant-cloud_accounts_hybrid_cloud_environment" "named_env" {
+  name = "platform-prod" // Update with the name of the environment to fetch
+}
+
+output "hybrid_cloud_environment" {
+  value = data.qdrant-cloud_accounts_hybrid_cloud_environment.specific_env
+}
+
+// Use the environment ID as the cloud region of a hybrid cluster
+output "named_env_cloud_region" {
+  value = data.qdrant-cloud_accounts_hybrid_cloud_environment.named_env.id
+}
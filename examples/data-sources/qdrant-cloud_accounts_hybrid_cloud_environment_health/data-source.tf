@@ -0,0 +1,41 @@
+// Setup Terraform, including the qdrant-cloud providers
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.1.0"
+    }
+  }
+}
+
+// Add the provider to specify some provider wide settings
+provider "qdrant-cloud" {
+  api_key    = "" // API Key generated in Qdrant Cloud (required)
+  account_id = "" // The default account ID you want to use in Qdrant Cloud (can be overriden on resource level)
+}
+
+data "qdrant-cloud_accounts_hybrid_cloud_environment_health" "env" {
+  hybrid_cloud_environment_id = "00000000-0000-0000-0000-000000000000" // Update with the ID of the environment
+}
+
+// Report an unhealthy environment (as a warning) on every plan and apply
+check "hybrid_cloud_environment_health" {
+  assert {
+    condition     = data.qdrant-cloud_accounts_hybrid_cloud_environment_health.env.healthy
+    error_message = "Hybrid cloud environment is not healthy: ${data.qdrant-cloud_accounts_hybrid_cloud_environment_health.env.reason}"
+  }
+}
+
+output "unhealthy_components" {
+  value = data.qdrant-cloud_accounts_hybrid_cloud_environment_health.env.unhealthy_components
+}
+
+output "kubernetes_version" {
+  value = data.qdrant-cloud_accounts_hybrid_cloud_environment_health.env.kubernetes_version
+}
+
+// Component versions by name
+output "component_versions" {
+  value = { for c in data.qdrant-cloud_accounts_hybrid_cloud_environment_health.env.components : c.name => c.version }
+}
@@ -0,0 +1,33 @@
+// Setup Terraform, including the qdrant-cloud providers
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.1.0"
+    }
+  }
+}
+
+// Add the provider to specify some provider wide settings
+provider "qdrant-cloud" {
+  api_key    = "" // API Key generated in Qdrant Cloud (required)
+  account_id = "" // The default account ID you want to use in Qdrant Cloud (can be overriden on resource level)
+}
+
+data "qdrant-cloud_accounts_hybrid_cloud_environments" "all_envs" {
+  // No keys needed here, the account ID is specified on provider level
+}
+
+output "hybrid_cloud_environments" {
+  value = data.qdrant-cloud_accounts_hybrid_cloud_environments.all_envs.hybrid_cloud_environments
+}
+
+// List the hybrid cloud environments which are ready
+data "qdrant-cloud_accounts_hybrid_cloud_environments" "ready_envs" {
+  phase = "HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_READY"
+}
+
+output "ready_env_ids" {
+  value = data.qdrant-cloud_accounts_hybrid_cloud_environments.ready_envs.hybrid_cloud_environments[*].id
+}
@@ -0,0 +1,36 @@
+// Setup Terraform, including the qdrant-cloud providers
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.1.0"
+    }
+  }
+}
+
+// Add the provider to specify some provider wide settings
+provider "qdrant-cloud" {
+  api_key    = "" // API Key generated in Qdrant Cloud (required)
+  account_id = "" // The default account ID you want to use in Qdrant Cloud (can be overriden on resource level)
+}
+
+// Select the cheapest package with at least 8GiB RAM, 2 vCPU and 64GiB disk, without a GPU
+data "qdrant-cloud_booking_package" "test" {
+  cloud_provider = "aws"       // Required. Please refer to the documentation (https://registry.terraform.io/providers/qdrant/qdrant-cloud/latest/docs/guides/getting-started) for the available options.
+  cloud_region   = "us-west-2" // Required. Please refer to the documentation (https://registry.terraform.io/providers/qdrant/qdrant-cloud/latest/docs/guides/getting-started) for the available options.
+  min_ram        = "8GiB"
+  min_cpu        = "2"
+  min_disk       = "64GiB"
+  gpu            = false
+}
+
+// Output the package id (which is relevant for creating a cluster)
+output "package_id" {
+  value = data.qdrant-cloud_booking_package.test.id
+}
+
+// Output the price of the package
+output "package_price_per_hour" {
+  value = data.qdrant-cloud_booking_package.test.unit_int_price_per_hour
+}
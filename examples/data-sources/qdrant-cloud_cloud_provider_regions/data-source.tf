@@ -0,0 +1,24 @@
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.13.0"
+    }
+  }
+}
+
+provider "qdrant-cloud" {
+  api_key    = "" # API Key generated in Qdrant Cloud (required)
+  account_id = "" # Default account ID (can be overridden per data source)
+}
+
+# List all regions of AWS
+data "qdrant-cloud_cloud_provider_regions" "aws" {
+  cloud_provider = "aws"
+}
+
+# Output the IDs of the available regions in Germany
+output "german_regions" {
+  value = [for region in data.qdrant-cloud_cloud_provider_regions.aws.regions : region.id if region.available && region.country_iso_code == "DE"]
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.13.0"
+    }
+  }
+}
+
+provider "qdrant-cloud" {
+  api_key    = "" # API Key generated in Qdrant Cloud (required)
+  account_id = "" # Default account ID (can be overridden per data source)
+}
+
+# List all cloud providers
+data "qdrant-cloud_cloud_providers" "all" {}
+
+# Output the IDs of the available cloud providers
+output "available_cloud_providers" {
+  value = [for provider in data.qdrant-cloud_cloud_providers.all.cloud_providers : provider.id if provider.available]
+}
@@ -0,0 +1,76 @@
+// Setup Terraform, including the qdrant-cloud providers
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.1.0"
+    }
+  }
+}
+
+// Add the provider to specify some provider wide settings
+provider "qdrant-cloud" {
+  api_key    = "" // API Key generated in Qdrant Cloud (required)
+  account_id = "" // The default account ID you want to use in Qdrant Cloud (can be overriden on resource level)
+}
+
+// Get the cluster package
+data "qdrant-cloud_booking_packages" "all_packages" {
+  cloud_provider = "aws"       // Required. Please refer to the documentation (https://registry.terraform.io/providers/qdrant/qdrant-cloud/latest/docs/guides/getting-started) for the available options.
+  cloud_region   = "us-west-2" // Required. Please refer to the documentation (https://registry.terraform.io/providers/qdrant/qdrant-cloud/latest/docs/guides/getting-started) for the available options.
+}
+locals {
+  desired_package = [
+    for pkg in data.qdrant-cloud_booking_packages.all_packages.packages : pkg
+    if pkg.resource_configuration[0].cpu == "16000m" && pkg.resource_configuration[0].ram == "64Gi"
+  ]
+}
+
+// Create a cluster
+resource "qdrant-cloud_accounts_cluster" "example" {
+  name           = "example-cluster"
+  cloud_provider = data.qdrant-cloud_booking_packages.all_packages.cloud_provider
+  cloud_region   = data.qdrant-cloud_booking_packages.all_packages.cloud_region
+  configuration {
+    number_of_nodes = 1
+    node_configuration {
+      package_id = local.desired_package[0].id
+    }
+  }
+}
+
+// Create a tiered Backup policy, which refers to the cluster provided above
+resource "qdrant-cloud_accounts_backup_policy" "example" {
+  cluster_id = qdrant-cloud_accounts_cluster.example.id
+  hour       = 2 // Daily at 02:00 UTC, weekly at 03:00 and monthly at 04:00
+  daily {
+    retention_period = "168h" // Retain daily backups for 7 days
+  }
+  weekly {
+    day_of_week      = 0       // Sunday
+    retention_period = "672h"  // Retain weekly backups for 4 weeks
+  }
+  monthly {
+    day_of_month     = 1       // First day of the month
+    retention_period = "8760h" // Retain monthly backups for 1 year
+  }
+}
+
+// Output some of the cluster info
+output "cluster_id" {
+  value = qdrant-cloud_accounts_cluster.example.id
+}
+
+output "cluster_version" {
+  value = qdrant-cloud_accounts_cluster.example.version
+}
+
+output "url" {
+  value = qdrant-cloud_accounts_cluster.example.url
+}
+
+// Output the Backup Schedules created by the policy
+output "backup_policy_schedules" {
+  value = qdrant-cloud_accounts_backup_policy.example.schedules
+}
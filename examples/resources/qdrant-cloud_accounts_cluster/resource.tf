@@ -46,6 +46,14 @@ resource "qdrant-cloud_accounts_cluster" "example" {
       storage_tier_type = "STORAGE_TIER_TYPE_BALANCED"
     }
   }
+  // Take a backup before the cluster is destroyed, which is kept for 30 days
+  final_backup {
+    retention_period = "720h"
+  }
+  // Take a backup before version upgrades, node count decreases or package changes, which is kept for 7 days
+  backup_before_update {
+    retention_period = "168h"
+  }
 }
 
 // Create an V2 Auth Key, which refers to the cluster provided above
@@ -67,6 +75,19 @@ output "url" {
   value = qdrant-cloud_accounts_cluster.example.url
 }
 
+// Output the structured connection details, e.g. to generate app configs or Kubernetes secrets
+output "rest_url" {
+  value = qdrant-cloud_accounts_cluster.example.endpoint[0].rest_url
+}
+
+output "grpc_url" {
+  value = qdrant-cloud_accounts_cluster.example.endpoint[0].grpc_url
+}
+
+output "python_snippet" {
+  value = qdrant-cloud_accounts_cluster.example.connection_snippets[0].python
+}
+
 // Output the Database API Key (which can be used to access the database cluster)
 output "key" {
   value = qdrant-cloud_accounts_database_api_key_v2.example-key.key
@@ -0,0 +1,40 @@
+// Setup Terraform, including the qdrant-cloud and time providers
+terraform {
+  required_version = ">= 1.7.0"
+  required_providers {
+    qdrant-cloud = {
+      source  = "qdrant/qdrant-cloud"
+      version = ">=1.1.0"
+    }
+    time = {
+      source = "hashicorp/time"
+    }
+  }
+}
+
+// Add the provider to specify some provider wide settings
+provider "qdrant-cloud" {
+  api_key    = "" // API Key generated in Qdrant Cloud (required)
+  account_id = "" // The default account ID you want to use in Qdrant Cloud (can be overriden on resource level)
+}
+
+// Rotate the key every 30 days
+resource "time_rotating" "ci" {
+  rotation_days = 30
+}
+
+// Create a Management Key for a CI pipeline, replaced when the rotation time is reached
+resource "qdrant-cloud_accounts_management_key" "ci" {
+  rotate_after = time_rotating.ci.rotation_rfc3339
+
+  lifecycle {
+    // Create the new key before the old one is deleted
+    create_before_destroy = true
+  }
+}
+
+// Output the Management Key (which can be used as api_key of the provider in the CI pipeline)
+output "ci_management_key" {
+  value     = qdrant-cloud_accounts_management_key.ci.key
+  sensitive = true
+}
@@ -0,0 +1,122 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+)
+
+// bookingPackageCache caches the booking packages (per account, cloud provider and region)
+// for the duration of a provider run, so plan-time validations don't list them for every resource.
+type bookingPackageCache struct {
+	packages *catalogCache[[]*qcBooking.Package]
+}
+
+// newBookingPackageCache creates an empty booking package cache.
+func newBookingPackageCache() *bookingPackageCache {
+	return &bookingPackageCache{packages: newCatalogCache[[]*qcBooking.Package]()}
+}
+
+// listPackages returns the packages for the provided account, cloud provider and (optional) region.
+// The packages are fetched from the API once per key, subsequent calls return the cached result.
+// A nil cache fetches the packages for every call.
+func (c *bookingPackageCache) listPackages(ctx context.Context, client qcBooking.BookingServiceClient, accountID, cloudProvider, cloudRegion string) ([]*qcBooking.Package, error) {
+	fetch := func() ([]*qcBooking.Package, error) {
+		req := &qcBooking.ListPackagesRequest{
+			AccountId:       accountID,
+			CloudProviderId: cloudProvider,
+		}
+		if cloudRegion != "" {
+			req.CloudProviderRegionId = newPointer(cloudRegion)
+		}
+		var trailer metadata.MD
+		resp, err := client.ListPackages(ctx, req, grpc.Trailer(&trailer))
+		if err != nil {
+			return nil, fmt.Errorf("error listing packages%s: %w", getRequestID(trailer), err)
+		}
+		return resp.GetItems(), nil
+	}
+	if c == nil {
+		return fetch()
+	}
+	return c.packages.get(strings.Join([]string{accountID, cloudProvider, cloudRegion}, "/"), fetch)
+}
+
+// getBookingPackageCache returns the booking package cache of the provider (or nil if not available).
+func getBookingPackageCache(m interface{}) *bookingPackageCache {
+	clientConfig, ok := m.(*ProviderConfig)
+	if !ok {
+		return nil
+	}
+	return clientConfig.packageCache
+}
+
+// findBookingPackage returns the package with the provided ID (or nil if not found).
+func findBookingPackage(packages []*qcBooking.Package, packageID string) *qcBooking.Package {
+	for _, p := range packages {
+		if p.GetId() == packageID {
+			return p
+		}
+	}
+	return nil
+}
+
+// validateClusterPackage ensures the package exists and is active in the region of the cluster.
+func validateClusterPackage(packages []*qcBooking.Package, packageID, cloudProvider, cloudRegion string) (*qcBooking.Package, error) {
+	pkg := findBookingPackage(packages, packageID)
+	if pkg == nil {
+		return nil, fmt.Errorf("package %q is not offered in %s/%s", packageID, cloudProvider, cloudRegion)
+	}
+	if pkg.GetStatus() != qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE {
+		return nil, fmt.Errorf("package %q (%s) is not available (%s)", packageID, pkg.GetName(), pkg.GetStatus().String())
+	}
+	return pkg, nil
+}
+
+// validateClusterAdditionalResources ensures only supported additional resources are configured
+// (disk in Gi, see compatibility.go), and that the package allows additional resources at all (if known).
+func validateClusterAdditionalResources(pkg *qcBooking.Package, resourceConfigurations []interface{}) error {
+	for _, v := range resourceConfigurations {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resourceType, _ := item[resourceConfigurationResourceTypeFieldName].(string)
+		resourceUnit, _ := item[resourceConfigurationResourceUnitFieldName].(string)
+		if ResourceType(resourceType) != ResourceTypeDisk {
+			return fmt.Errorf("additional resource type %q is not supported, only %q can be added", resourceType, ResourceTypeDisk)
+		}
+		if ResourceUnit(resourceUnit) != ResourceUnitGi {
+			return fmt.Errorf("additional resource unit %q is not supported for %q, only %q can be used", resourceUnit, resourceType, ResourceUnitGi)
+		}
+		if pkg != nil && pkg.GetAvailableAdditionalResources() == nil {
+			return fmt.Errorf("package %q (%s) does not allow additional %s", pkg.GetId(), pkg.GetName(), resourceType)
+		}
+	}
+	return nil
+}
+
+// validateClusterStorageTier ensures the storage tier is offered for the package.
+// Packages without any storage tier configurations are not validated.
+func validateClusterStorageTier(pkg *qcBooking.Package, storageTierType string) error {
+	configs := pkg.GetAvailableStorageTierConfigurations()
+	if storageTierType == "" || len(configs) == 0 {
+		return nil
+	}
+	offered := make([]string, 0, len(configs))
+	for _, c := range configs {
+		if c.GetStorageTierType().String() == storageTierType {
+			return nil
+		}
+		offered = append(offered, c.GetStorageTierType().String())
+	}
+	sort.Strings(offered)
+	return fmt.Errorf("storage tier %q is not offered for package %q (%s), must be one of: %s",
+		storageTierType, pkg.GetId(), pkg.GetName(), strings.Join(offered, ", "))
+}
@@ -0,0 +1,139 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+type mockBookingServiceClient struct {
+	qcBooking.BookingServiceClient
+	packages  []*qcBooking.Package
+	err       error
+	requests  []*qcBooking.ListPackagesRequest
+	callCount int
+}
+
+func (m *mockBookingServiceClient) ListPackages(_ context.Context, in *qcBooking.ListPackagesRequest, _ ...grpc.CallOption) (*qcBooking.ListPackagesResponse, error) {
+	m.callCount++
+	m.requests = append(m.requests, in)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &qcBooking.ListPackagesResponse{Items: m.packages}, nil
+}
+
+func TestBookingPackageCache_ListPackagesOnce(t *testing.T) {
+	client := &mockBookingServiceClient{packages: []*qcBooking.Package{{Id: "pkg-1"}}}
+	cache := newBookingPackageCache()
+
+	for i := 0; i < 3; i++ {
+		packages, err := cache.listPackages(context.Background(), client, "account-1", "aws", "eu-central-1")
+		require.NoError(t, err)
+		assert.Len(t, packages, 1)
+	}
+	assert.Equal(t, 1, client.callCount)
+	assert.Equal(t, "eu-central-1", client.requests[0].GetCloudProviderRegionId())
+
+	// Another region is fetched separately
+	_, err := cache.listPackages(context.Background(), client, "account-1", "aws", "us-east-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.callCount)
+}
+
+func TestBookingPackageCache_ErrorNotCached(t *testing.T) {
+	client := &mockBookingServiceClient{err: errors.New("unavailable")}
+	cache := newBookingPackageCache()
+
+	_, err := cache.listPackages(context.Background(), client, "account-1", "aws", "eu-central-1")
+	assert.ErrorContains(t, err, "unavailable")
+
+	client.err = nil
+	_, err = cache.listPackages(context.Background(), client, "account-1", "aws", "eu-central-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.callCount)
+}
+
+func TestBookingPackageCache_Nil(t *testing.T) {
+	client := &mockBookingServiceClient{}
+	var cache *bookingPackageCache
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.listPackages(context.Background(), client, "account-1", "aws", "eu-central-1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, client.callCount)
+	assert.Nil(t, getBookingPackageCache(nil))
+}
+
+func TestValidateClusterPackage(t *testing.T) {
+	packages := []*qcBooking.Package{
+		{Id: "active", Name: "active", Status: qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE},
+		{Id: "deactivated", Name: "deactivated", Status: qcBooking.PackageStatus_PACKAGE_STATUS_DEACTIVATED},
+	}
+
+	pkg, err := validateClusterPackage(packages, "active", "aws", "eu-central-1")
+	require.NoError(t, err)
+	assert.Equal(t, "active", pkg.GetId())
+
+	_, err = validateClusterPackage(packages, "deactivated", "aws", "eu-central-1")
+	assert.ErrorContains(t, err, "is not available")
+
+	_, err = validateClusterPackage(packages, "unknown", "aws", "eu-central-1")
+	assert.ErrorContains(t, err, `package "unknown" is not offered in aws/eu-central-1`)
+}
+
+func TestValidateClusterAdditionalResources(t *testing.T) {
+	disk := []interface{}{
+		map[string]interface{}{
+			resourceConfigurationAmountFieldName:       10,
+			resourceConfigurationResourceTypeFieldName: "disk",
+			resourceConfigurationResourceUnitFieldName: "Gi",
+		},
+	}
+	assert.NoError(t, validateClusterAdditionalResources(nil, disk))
+	assert.NoError(t, validateClusterAdditionalResources(&qcBooking.Package{
+		AvailableAdditionalResources: &qcBooking.AvailableAdditionalResources{DiskPricePerHour: 1},
+	}, disk))
+	assert.ErrorContains(t, validateClusterAdditionalResources(&qcBooking.Package{Id: "pkg"}, disk), "does not allow additional disk")
+
+	ram := []interface{}{
+		map[string]interface{}{
+			resourceConfigurationAmountFieldName:       1,
+			resourceConfigurationResourceTypeFieldName: "ram",
+			resourceConfigurationResourceUnitFieldName: "Gi",
+		},
+	}
+	assert.ErrorContains(t, validateClusterAdditionalResources(nil, ram), `additional resource type "ram" is not supported`)
+
+	millis := []interface{}{
+		map[string]interface{}{
+			resourceConfigurationAmountFieldName:       1,
+			resourceConfigurationResourceTypeFieldName: "disk",
+			resourceConfigurationResourceUnitFieldName: "m",
+		},
+	}
+	assert.ErrorContains(t, validateClusterAdditionalResources(nil, millis), `additional resource unit "m" is not supported`)
+}
+
+func TestValidateClusterStorageTier(t *testing.T) {
+	pkg := &qcBooking.Package{
+		Id:   "pkg",
+		Name: "pkg",
+		AvailableStorageTierConfigurations: []*qcBooking.AvailableStoragePerformanceTierConfigurations{
+			{StorageTierType: commonv1.StorageTierType_STORAGE_TIER_TYPE_BALANCED},
+		},
+	}
+	assert.NoError(t, validateClusterStorageTier(pkg, ""))
+	assert.NoError(t, validateClusterStorageTier(pkg, "STORAGE_TIER_TYPE_BALANCED"))
+	assert.ErrorContains(t, validateClusterStorageTier(pkg, "STORAGE_TIER_TYPE_PERFORMANCE"), "must be one of: STORAGE_TIER_TYPE_BALANCED")
+	// Packages without storage tier configurations are not validated
+	assert.NoError(t, validateClusterStorageTier(&qcBooking.Package{}, "STORAGE_TIER_TYPE_PERFORMANCE"))
+}
@@ -0,0 +1,53 @@
+package qdrant
+
+import (
+	"sync"
+)
+
+// catalogCache caches catalog values (e.g. booking packages) per key for the duration of a provider run.
+// Every key has its own lock, so a key is fetched once while fetches of different keys don't block each other.
+type catalogCache[T any] struct {
+	mu      sync.Mutex
+	entries map[string]*catalogCacheEntry[T]
+}
+
+// catalogCacheEntry is the cached value of a single key of a catalogCache.
+type catalogCacheEntry[T any] struct {
+	mu     sync.Mutex
+	value  T
+	cached bool
+}
+
+// newCatalogCache creates an empty catalog cache.
+func newCatalogCache[T any]() *catalogCache[T] {
+	return &catalogCache[T]{entries: map[string]*catalogCacheEntry[T]{}}
+}
+
+// get returns the cached value of the provided key, or fetches (and caches) it if not cached yet.
+// Concurrent calls for the same key wait for a single fetch, errors are not cached.
+// A nil cache fetches the value for every call.
+func (c *catalogCache[T]) get(key string, fetch func() (T, error)) (T, error) {
+	if c == nil {
+		return fetch()
+	}
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &catalogCacheEntry[T]{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.cached {
+		return entry.value, nil
+	}
+	value, err := fetch()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	entry.value, entry.cached = value, true
+	return value, nil
+}
@@ -0,0 +1,74 @@
+package qdrant
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogCache_FetchOncePerKey(t *testing.T) {
+	cache := newCatalogCache[int]()
+	var calls atomic.Int32
+	fetch := func() (int, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.get("key", fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, value)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCatalogCache_KeysDontBlockEachOther(t *testing.T) {
+	cache := newCatalogCache[string]()
+	fetchedB := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Fetching key a waits for key b, which would dead-lock with a single lock held during the fetch.
+		_, err := cache.get("a", func() (string, error) {
+			select {
+			case <-fetchedB:
+				return "a", nil
+			case <-time.After(5 * time.Second):
+				return "", errors.New("key b has not been fetched while fetching key a")
+			}
+		})
+		assert.NoError(t, err)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	value, err := cache.get("b", func() (string, error) { return "b", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "b", value)
+	close(fetchedB)
+	<-done
+}
+
+func TestCatalogCache_ErrorNotCached(t *testing.T) {
+	cache := newCatalogCache[int]()
+	_, err := cache.get("key", func() (int, error) { return 0, errors.New("unavailable") })
+	assert.ErrorContains(t, err, "unavailable")
+	value, err := cache.get("key", func() (int, error) { return 1, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	var nilCache *catalogCache[int]
+	value, err = nilCache.get("key", func() (int, error) { return 2, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 2, value)
+}
@@ -0,0 +1,123 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+// clusterBudgetOverrideLabelKey is the key of the cluster label which exempts a cluster from the budget limits,
+// to be used for approved exceptions.
+const clusterBudgetOverrideLabelKey = "budget-override"
+
+// enforceClusterBudget refuses plans which create a cluster, or increase the cost of a cluster, beyond the
+// max_cluster_monthly_cost or max_account_monthly_cost limits of the provider.
+// Decreases are always allowed, so an over-budget cluster can be scaled down.
+// The limits fail closed: if the cost of the cluster, or the cost of the other clusters in the account, cannot be determined
+// during plan (e.g. unknown values or the catalog is not available), the plan fails unless the cluster has the override label.
+// Other clusters which cannot be priced are left out of the account total (with a warning in the logs).
+// Note: it should be called after setClusterCostEstimate, which sets the estimated cost during plan.
+func enforceClusterBudget(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	clientConfig, ok := m.(*ProviderConfig)
+	if !ok || (clientConfig.MaxClusterMonthlyCost <= 0 && clientConfig.MaxAccountMonthlyCost <= 0) {
+		return nil
+	}
+	if d.NewValueKnown(clusterLabelsFieldName) {
+		if labels, ok := d.Get(clusterLabelsFieldName).(*schema.Set); ok && hasBudgetOverrideLabel(labels.List()) {
+			return nil
+		}
+	}
+	if !d.NewValueKnown(clusterEstimatedMonthlyCostFieldName) {
+		tflog.Warn(ctx, "The estimated cost of the cluster is unknown during plan, refusing the plan as budget limits are configured")
+		return unknownBudgetError("the estimated monthly cost of the cluster is unknown during plan (e.g. it depends on values known after apply, or the booking catalog cannot be read)")
+	}
+	oldCost, newCost := d.GetChange(clusterEstimatedMonthlyCostFieldName)
+	if d.Id() != "" && newCost.(float64) <= oldCost.(float64) {
+		return nil
+	}
+	cost := newCost.(float64)
+	currency := d.Get(clusterEstimatedCostCurrencyFieldName).(string)
+	if err := checkMonthlyBudget("the cluster", cost, "max_cluster_monthly_cost", clientConfig.MaxClusterMonthlyCost, currency); err != nil {
+		return err
+	}
+	if clientConfig.MaxAccountMonthlyCost <= 0 {
+		return nil
+	}
+	// The other clusters of the account are taken into account with their current cost.
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		tflog.Warn(ctx, "The account of the cluster is unknown, refusing the plan as max_account_monthly_cost is configured", map[string]interface{}{"error": err.Error()})
+		return unknownBudgetError(fmt.Sprintf("the account of the cluster is unknown (%s)", err))
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, qcCluster.NewClusterServiceClient)
+	if diags.HasError() {
+		tflog.Warn(ctx, "No client available to list the clusters of the account, refusing the plan as max_account_monthly_cost is configured", map[string]interface{}{"error": diags[0].Summary})
+		return unknownBudgetError(fmt.Sprintf("the clusters of the account cannot be listed (%s)", diags[0].Summary))
+	}
+	clusters, err := listClusters(clientCtx, client, accountUUID.String())
+	if err != nil {
+		tflog.Warn(ctx, "Cannot list the clusters of the account, refusing the plan as max_account_monthly_cost is configured", map[string]interface{}{"error": err.Error()})
+		return unknownBudgetError(fmt.Sprintf("the clusters of the account cannot be listed (%s)", err))
+	}
+	others := sumClustersMonthlyCost(clusters, d.Id(), currency, func(cluster *qcCluster.Cluster) (clusterCostEstimate, bool) {
+		estimate, ok, err := lookupClusterCostEstimate(ctx, m, accountUUID.String(), cluster)
+		if err != nil {
+			tflog.Warn(ctx, "Cannot estimate the cost of a cluster of the account, it is not part of the account total", map[string]interface{}{
+				"cluster_id": cluster.GetId(),
+				"error":      err.Error(),
+			})
+		}
+		return estimate, ok && err == nil
+	})
+	return checkMonthlyBudget("all clusters in the account", others+cost, "max_account_monthly_cost", clientConfig.MaxAccountMonthlyCost, currency)
+}
+
+// unknownBudgetError returns the error reported when the budget limits cannot be enforced, because of the provided reason.
+func unknownBudgetError(reason string) error {
+	return fmt.Errorf("the budget limits of the provider cannot be enforced: %s, add the label %q with value \"true\" to the cluster to approve an exception",
+		reason, clusterBudgetOverrideLabelKey)
+}
+
+// hasBudgetOverrideLabel returns true if the provided (flattened) labels contain the budget override label set to "true".
+func hasBudgetOverrideLabel(labels []interface{}) bool {
+	for _, v := range labels {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if item["key"] == clusterBudgetOverrideLabelKey && item["value"] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// sumClustersMonthlyCost returns the total estimated monthly cost (in the provided currency) of the provided clusters,
+// excluding the cluster with the provided ID. Clusters which cannot be priced, or are priced in another currency, are skipped.
+func sumClustersMonthlyCost(clusters []*qcCluster.Cluster, excludeID, currency string, estimator clusterCostEstimator) float64 {
+	var total float64
+	for _, cluster := range clusters {
+		if excludeID != "" && cluster.GetId() == excludeID {
+			continue
+		}
+		estimate, ok := estimator(cluster)
+		if !ok || estimate.Currency != currency {
+			continue
+		}
+		total += estimate.MonthlyCost
+	}
+	return roundPrice(total, 2)
+}
+
+// checkMonthlyBudget returns an error if the provided estimated monthly cost exceeds the (non-zero) limit.
+func checkMonthlyBudget(subject string, cost float64, limitName string, limit float64, currency string) error {
+	if limit <= 0 || cost <= limit {
+		return nil
+	}
+	return fmt.Errorf("the estimated monthly cost of %s (%.2f %s) exceeds %s (%.2f), add the label %q with value \"true\" to the cluster to approve an exception",
+		subject, cost, currency, limitName, limit, clusterBudgetOverrideLabelKey)
+}
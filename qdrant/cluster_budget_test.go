@@ -0,0 +1,92 @@
+package qdrant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+func TestHasBudgetOverrideLabel(t *testing.T) {
+	assert.False(t, hasBudgetOverrideLabel(nil))
+	assert.False(t, hasBudgetOverrideLabel([]interface{}{
+		map[string]interface{}{"key": "team", "value": "search"},
+	}))
+	assert.False(t, hasBudgetOverrideLabel([]interface{}{
+		map[string]interface{}{"key": clusterBudgetOverrideLabelKey, "value": "false"},
+	}))
+	assert.True(t, hasBudgetOverrideLabel([]interface{}{
+		map[string]interface{}{"key": "team", "value": "search"},
+		map[string]interface{}{"key": clusterBudgetOverrideLabelKey, "value": "true"},
+	}))
+}
+
+func TestSumClustersMonthlyCost(t *testing.T) {
+	clusters := []*qcCluster.Cluster{{Id: "c1"}, {Id: "c2"}, {Id: "c3"}, {Id: "c4"}}
+	estimates := map[string]clusterCostEstimate{
+		"c1": {MonthlyCost: 73, Currency: "usd"},
+		"c2": {MonthlyCost: 219, Currency: "usd"},
+		"c3": {MonthlyCost: 146, Currency: "eur"},
+	}
+	estimator := func(cluster *qcCluster.Cluster) (clusterCostEstimate, bool) {
+		estimate, ok := estimates[cluster.GetId()]
+		return estimate, ok
+	}
+
+	assert.Equal(t, 292.0, sumClustersMonthlyCost(clusters, "", "usd", estimator))
+	// The cluster being planned is excluded
+	assert.Equal(t, 73.0, sumClustersMonthlyCost(clusters, "c2", "usd", estimator))
+	assert.Equal(t, 146.0, sumClustersMonthlyCost(clusters, "", "eur", estimator))
+}
+
+func TestCheckMonthlyBudget(t *testing.T) {
+	// No limit
+	assert.NoError(t, checkMonthlyBudget("the cluster", 10000, "max_cluster_monthly_cost", 0, "usd"))
+	// Within the limit
+	assert.NoError(t, checkMonthlyBudget("the cluster", 1000, "max_cluster_monthly_cost", 1000, "usd"))
+	// Exceeding the limit
+	err := checkMonthlyBudget("the cluster", 21900, "max_cluster_monthly_cost", 1000, "usd")
+	assert.ErrorContains(t, err, "the estimated monthly cost of the cluster (21900.00 usd) exceeds max_cluster_monthly_cost (1000.00)")
+	assert.ErrorContains(t, err, clusterBudgetOverrideLabelKey)
+}
+
+func TestEnforceClusterBudget_NoLimits(t *testing.T) {
+	// Without limits the diff isn't inspected at all.
+	assert.NoError(t, enforceClusterBudget(context.Background(), nil, &ProviderConfig{}))
+	assert.NoError(t, enforceClusterBudget(context.Background(), nil, nil))
+}
+
+func TestEnforceClusterBudget_UnknownCost(t *testing.T) {
+	r := resourceAccountsCluster()
+	config := func(labels []interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":           "test-cluster",
+			"cloud_provider": "aws",
+			"cloud_region":   "eu-central-1",
+			"labels":         labels,
+			"configuration": []interface{}{
+				map[string]interface{}{
+					"number_of_nodes": 30,
+					"node_configuration": []interface{}{
+						map[string]interface{}{"package_id": "pkg-1"},
+					},
+				},
+			},
+		})
+	}
+	// Without a client the catalog cannot be read, so the cost of the new cluster is unknown.
+	m := &ProviderConfig{MaxClusterMonthlyCost: 1000}
+
+	_, err := r.Diff(context.Background(), nil, config(nil), m)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "the budget limits of the provider cannot be enforced")
+
+	_, err = r.Diff(context.Background(), nil, config([]interface{}{
+		map[string]interface{}{"key": clusterBudgetOverrideLabelKey, "value": "true"},
+	}), m)
+	assert.NoError(t, err)
+}
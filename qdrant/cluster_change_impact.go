@@ -0,0 +1,227 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterChangeImpact describes the impact of a change of a cluster field.
+type clusterChangeImpact string
+
+// Impacts of cluster changes, ordered by severity.
+const (
+	// The change is applied without restarting the nodes.
+	clusterChangeImpactInPlace clusterChangeImpact = "in-place"
+	// The nodes are restarted one by one, the cluster stays available (if the collections are replicated).
+	clusterChangeImpactRollingRestart clusterChangeImpact = "rolling restart"
+	// The single node of the cluster is restarted, the cluster is unavailable during the restart.
+	clusterChangeImpactDowntime clusterChangeImpact = "downtime"
+	// Shards are moved off the removed nodes, data of shards without replicas is lost if moving them fails.
+	clusterChangeImpactResharding clusterChangeImpact = "resharding"
+	// The change cannot be reverted afterwards.
+	clusterChangeImpactIrreversible clusterChangeImpact = "irreversible"
+	// The change cannot be applied (e.g. shrinking the disk), the plan fails.
+	clusterChangeImpactInvalid clusterChangeImpact = "invalid"
+)
+
+// clusterChangeImpactSeverity contains the severity of every impact (higher is more severe).
+var clusterChangeImpactSeverity = map[clusterChangeImpact]int{
+	clusterChangeImpactInPlace:        0,
+	clusterChangeImpactRollingRestart: 1,
+	clusterChangeImpactDowntime:       2,
+	clusterChangeImpactResharding:     3,
+	clusterChangeImpactIrreversible:   4,
+	clusterChangeImpactInvalid:        5,
+}
+
+// clusterFieldChange is the classified change of a single cluster field.
+type clusterFieldChange struct {
+	Field  string
+	Impact clusterChangeImpact
+	Reason string
+}
+
+// String returns a human readable description of the change, e.g. "configuration.version: rolling restart (...)".
+func (c clusterFieldChange) String() string {
+	return fmt.Sprintf("%s: %s (%s)", c.Field, c.Impact, c.Reason)
+}
+
+// clusterChangeDetector is implemented by both schema.ResourceData and schema.ResourceDiff.
+type clusterChangeDetector interface {
+	clusterChangeGetter
+	HasChange(key string) bool
+}
+
+// clusterChangeRule classifies the change of the fields with the provided (relative) keys.
+type clusterChangeRule struct {
+	keys     []string
+	restarts bool
+	reason   string
+}
+
+// clusterConfigurationChangeRules contains the rules for the configuration fields which don't need custom logic.
+var clusterConfigurationChangeRules = []clusterChangeRule{
+	{keys: []string{databaseConfigurationFieldName, dbConfigReservedCpuPercentageFieldName, dbConfigReservedMemoryPercentageFieldName, dbConfigGpuTypeFieldName},
+		restarts: true, reason: "the database configuration is applied by restarting the nodes"},
+	{keys: []string{nodeSelectorFieldName, tolerationsFieldName, topologySpreadConstraintsFieldName, annotationsFieldName, podLabelsFieldName},
+		restarts: true, reason: "the pods of the nodes are rescheduled"},
+	{keys: []string{clusterStorageConfigurationFieldName},
+		restarts: true, reason: "the nodes are restarted with the new storage configuration"},
+	{keys: []string{allowedIpSourceRangesFieldName, serviceTypeFieldName, serviceAnnotationsFieldName},
+		reason: "only the service (network access) of the cluster is updated"},
+	{keys: []string{dbConfigRestartPolicyFieldName, dbConfigRebalanceStrategyFieldName},
+		reason: "only affects future restarts and rebalancing"},
+}
+
+// classifyClusterChanges classifies every changed field of an existing cluster by its impact.
+// Changes which restart the nodes cause downtime if the cluster has a single node (before or after the change).
+// This is the single classification of cluster changes, also used to decide whether a change is disruptive (see isDisruptiveClusterChange).
+func classifyClusterChanges(d clusterChangeDetector) []clusterFieldChange {
+	configPrefix := fmt.Sprintf("%s.0.", configurationFieldName)
+	nodeConfigPrefix := configPrefix + nodeConfigurationFieldName + ".0."
+	o, n := d.GetChange(configPrefix + numberOfNodesFieldName)
+	oldNodes, _ := o.(int)
+	newNodes, _ := n.(int)
+	singleNode := oldNodes == 1 || newNodes == 1
+	restartImpact := func(reason string) (clusterChangeImpact, string) {
+		if singleNode {
+			return clusterChangeImpactDowntime, reason + ", the single node cluster is unavailable during the restart"
+		}
+		return clusterChangeImpactRollingRestart, reason + " one by one"
+	}
+
+	var result []clusterFieldChange
+	add := func(field string, impact clusterChangeImpact, reason string) {
+		result = append(result, clusterFieldChange{Field: strings.ReplaceAll(field, ".0.", "."), Impact: impact, Reason: reason})
+	}
+	for _, k := range []string{clusterNameFieldName, clusterLabelsFieldName} {
+		if d.HasChange(k) {
+			add(k, clusterChangeImpactInPlace, "metadata only")
+		}
+	}
+	// An unknown previous version (e.g. not read yet) doesn't restart anything.
+	if k := configPrefix + clusterVersionFieldName; d.HasChange(k) && !isEmptyChangeValue(d, k) {
+		impact, reason := restartImpact("the nodes are restarted with the new version")
+		add(k, impact, reason+", downgrades are not supported")
+	}
+	if k := configPrefix + numberOfNodesFieldName; d.HasChange(k) {
+		if newNodes > oldNodes {
+			add(k, clusterChangeImpactInPlace, fmt.Sprintf("%d node(s) are added, shards are rebalanced according to the rebalance strategy", newNodes-oldNodes))
+		} else {
+			add(k, clusterChangeImpactResharding, fmt.Sprintf("%d node(s) are removed, their shards are moved to the remaining nodes first, data of shards without replicas is lost if that fails", oldNodes-newNodes))
+		}
+	}
+	if k := nodeConfigPrefix + packageIDFieldName; d.HasChange(k) {
+		impact, reason := restartImpact("the nodes are restarted with the resources of the new package")
+		add(k, impact, reason)
+	}
+	if k := nodeConfigPrefix + resourceConfigurationsFieldName; d.HasChange(k) {
+		o, n := d.GetChange(k)
+		oldDisk := expandClusterNodeResourceConfigurationsToAdditionalResources(toInterfaceList(o)).GetDisk()
+		newDisk := expandClusterNodeResourceConfigurationsToAdditionalResources(toInterfaceList(n)).GetDisk()
+		switch {
+		case newDisk > oldDisk:
+			add(k, clusterChangeImpactIrreversible, fmt.Sprintf("the disk of every node grows from %d to %d Gi additional, disks cannot be shrunk afterwards", oldDisk, newDisk))
+		case newDisk < oldDisk:
+			add(k, clusterChangeImpactInvalid, fmt.Sprintf("the disk of every node would shrink from %d to %d Gi additional, disks cannot be shrunk", oldDisk, newDisk))
+		default:
+			impact, reason := restartImpact("the nodes are restarted with the new additional resources")
+			add(k, impact, reason)
+		}
+	}
+	for _, rule := range clusterConfigurationChangeRules {
+		for _, key := range rule.keys {
+			k := configPrefix + key
+			if !d.HasChange(k) {
+				continue
+			}
+			if rule.restarts {
+				impact, reason := restartImpact(rule.reason)
+				add(k, impact, reason)
+			} else {
+				add(k, clusterChangeImpactInPlace, rule.reason)
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return clusterChangeImpactSeverity[result[i].Impact] > clusterChangeImpactSeverity[result[j].Impact]
+	})
+	return result
+}
+
+// isEmptyChangeValue returns true if the previous value of the provided (string) field is empty.
+func isEmptyChangeValue(d clusterChangeGetter, key string) bool {
+	o, _ := d.GetChange(key)
+	old, _ := o.(string)
+	return old == ""
+}
+
+// isDisruptiveClusterChange returns true if any change of the existing cluster isn't applied in-place,
+// e.g. a version upgrade, a package change, a decrease of the number of nodes or a change which restarts the nodes.
+func isDisruptiveClusterChange(d clusterChangeDetector) bool {
+	for _, c := range classifyClusterChanges(d) {
+		if c.Impact != clusterChangeImpactInPlace {
+			return true
+		}
+	}
+	return false
+}
+
+// toInterfaceList returns the provided value as a list (or nil if it's not a list).
+func toInterfaceList(v interface{}) []interface{} {
+	list, _ := v.([]interface{})
+	return list
+}
+
+// flattenClusterFieldChanges creates a list of descriptions from the classified changes for easy storage in Terraform.
+func flattenClusterFieldChanges(changes []clusterFieldChange) []string {
+	result := make([]string, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, c.String())
+	}
+	return result
+}
+
+// setClusterChangeImpact classifies the changes of an existing cluster during plan and sets them as change_impact,
+// so the impact (restarts, downtime and irreversible changes) is visible in the plan output before apply.
+// The value is an empty list if there are no classified changes, and the plan fails if a change is invalid.
+func setClusterChangeImpact(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	changes := classifyClusterChanges(d)
+	var invalid []string
+	for _, c := range changes {
+		if c.Impact == clusterChangeImpactInvalid {
+			invalid = append(invalid, c.String())
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid cluster change: %s", strings.Join(invalid, "; "))
+	}
+	return d.SetNew(clusterChangeImpactFieldName, flattenClusterFieldChanges(changes))
+}
+
+// clusterChangeImpactWarnings returns a warning diagnostic summarizing the disruptive changes (if any).
+// The warning is only reported when the update is applied, change_impact is the signal in the plan output.
+func clusterChangeImpactWarnings(changes []clusterFieldChange) diag.Diagnostics {
+	var disruptive []string
+	for _, c := range changes {
+		if c.Impact != clusterChangeImpactInPlace {
+			disruptive = append(disruptive, "- "+c.String())
+		}
+	}
+	if len(disruptive) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Cluster update with %s impact", changes[0].Impact),
+		Detail:   "The following changes are disruptive:\n" + strings.Join(disruptive, "\n"),
+	}}
+}
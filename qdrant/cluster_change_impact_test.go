@@ -0,0 +1,121 @@
+package qdrant
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClusterChangeDetector implements clusterChangeDetector for testing.
+type fakeClusterChangeDetector struct {
+	fakeClusterChange
+}
+
+func (f fakeClusterChangeDetector) HasChange(key string) bool {
+	o, n := f.GetChange(key)
+	return !reflect.DeepEqual(o, n)
+}
+
+func TestClassifyClusterChanges(t *testing.T) {
+	nodesKey := "configuration.0.number_of_nodes"
+	base := func(oldNodes, newNodes int) fakeClusterChangeDetector {
+		return fakeClusterChangeDetector{fakeClusterChange{nodesKey: {oldNodes, newNodes}}}
+	}
+	disk := func(amount int) []interface{} {
+		return []interface{}{map[string]interface{}{"amount": amount, "resource_type": "disk", "resource_unit": "Gi"}}
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		assert.Empty(t, classifyClusterChanges(base(3, 3)))
+	})
+	t.Run("version upgrade of a multi node cluster", func(t *testing.T) {
+		c := base(3, 3)
+		c.fakeClusterChange["configuration.0.version"] = [2]interface{}{"v1.13.0", "v1.14.0"}
+		changes := classifyClusterChanges(c)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "configuration.version", changes[0].Field)
+		assert.Equal(t, clusterChangeImpactRollingRestart, changes[0].Impact)
+	})
+	t.Run("version upgrade of a single node cluster", func(t *testing.T) {
+		c := base(1, 1)
+		c.fakeClusterChange["configuration.0.version"] = [2]interface{}{"v1.13.0", "v1.14.0"}
+		changes := classifyClusterChanges(c)
+		require.Len(t, changes, 1)
+		assert.Equal(t, clusterChangeImpactDowntime, changes[0].Impact)
+	})
+	t.Run("node count", func(t *testing.T) {
+		changes := classifyClusterChanges(base(3, 5))
+		require.Len(t, changes, 1)
+		assert.Equal(t, "configuration.number_of_nodes", changes[0].Field)
+		assert.Equal(t, clusterChangeImpactInPlace, changes[0].Impact)
+		assert.Contains(t, changes[0].Reason, "2 node(s) are added")
+
+		changes = classifyClusterChanges(base(3, 2))
+		require.Len(t, changes, 1)
+		assert.Equal(t, clusterChangeImpactResharding, changes[0].Impact)
+		assert.Contains(t, changes[0].Reason, "1 node(s) are removed")
+	})
+	t.Run("disk growth", func(t *testing.T) {
+		c := base(3, 3)
+		c.fakeClusterChange["configuration.0.node_configuration.0.resource_configurations"] = [2]interface{}{disk(10), disk(20)}
+		changes := classifyClusterChanges(c)
+		require.Len(t, changes, 1)
+		assert.Equal(t, "configuration.node_configuration.resource_configurations", changes[0].Field)
+		assert.Equal(t, clusterChangeImpactIrreversible, changes[0].Impact)
+	})
+	t.Run("disk shrink", func(t *testing.T) {
+		c := base(3, 3)
+		c.fakeClusterChange["configuration.0.node_configuration.0.resource_configurations"] = [2]interface{}{disk(20), disk(10)}
+		changes := classifyClusterChanges(c)
+		require.Len(t, changes, 1)
+		assert.Equal(t, clusterChangeImpactInvalid, changes[0].Impact)
+		assert.Contains(t, changes[0].Reason, "cannot be shrunk")
+	})
+	t.Run("unknown previous version", func(t *testing.T) {
+		c := base(3, 3)
+		c.fakeClusterChange["configuration.0.version"] = [2]interface{}{"", "v1.14.0"}
+		assert.Empty(t, classifyClusterChanges(c))
+	})
+	t.Run("ordered by severity", func(t *testing.T) {
+		c := base(3, 3)
+		c.fakeClusterChange["name"] = [2]interface{}{"a", "b"}
+		c.fakeClusterChange["configuration.0.database_configuration"] = [2]interface{}{[]interface{}{}, []interface{}{map[string]interface{}{}}}
+		c.fakeClusterChange["configuration.0.node_configuration.0.resource_configurations"] = [2]interface{}{nil, disk(20)}
+		c.fakeClusterChange["configuration.0.allowed_ip_source_ranges"] = [2]interface{}{[]interface{}{}, []interface{}{"10.0.0.0/8"}}
+		var impacts []clusterChangeImpact
+		for _, change := range classifyClusterChanges(c) {
+			impacts = append(impacts, change.Impact)
+		}
+		assert.Equal(t, []clusterChangeImpact{
+			clusterChangeImpactIrreversible,
+			clusterChangeImpactRollingRestart,
+			clusterChangeImpactInPlace,
+			clusterChangeImpactInPlace,
+		}, impacts)
+	})
+}
+
+func TestClusterChangeImpactWarnings(t *testing.T) {
+	assert.Empty(t, clusterChangeImpactWarnings(nil))
+	assert.Empty(t, clusterChangeImpactWarnings([]clusterFieldChange{{Field: "name", Impact: clusterChangeImpactInPlace, Reason: "metadata only"}}))
+
+	diags := clusterChangeImpactWarnings([]clusterFieldChange{
+		{Field: "configuration.version", Impact: clusterChangeImpactDowntime, Reason: "restart"},
+		{Field: "name", Impact: clusterChangeImpactInPlace, Reason: "metadata only"},
+	})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Cluster update with downtime impact", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "- configuration.version: downtime (restart)")
+	assert.NotContains(t, diags[0].Detail, "name")
+}
+
+func TestFlattenClusterFieldChanges(t *testing.T) {
+	assert.Equal(t, []string{"configuration.version: rolling restart (the nodes are restarted)"}, flattenClusterFieldChanges([]clusterFieldChange{
+		{Field: "configuration.version", Impact: clusterChangeImpactRollingRestart, Reason: "the nodes are restarted"},
+	}))
+	assert.Empty(t, flattenClusterFieldChanges(nil))
+}
@@ -0,0 +1,161 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+const (
+	// bookingPriceUnitsPerCurrencyUnit is the amount of price units (millicents) in a single currency unit.
+	bookingPriceUnitsPerCurrencyUnit = 100000
+	// hoursPerMonth is the average amount of hours in a month (365 * 24 / 12).
+	hoursPerMonth = 730
+)
+
+// clusterCostEstimate is the estimated cost of a cluster, based on the booking catalog.
+type clusterCostEstimate struct {
+	PricePerHour float64
+	MonthlyCost  float64
+	Currency     string
+}
+
+// estimateClusterCost estimates the cost of a cluster using the provided package.
+// The price of a single node consists of the package price, the price of the additional disk (in Gi)
+// and the price of the storage tier (if any), which is multiplied by the number of nodes.
+func estimateClusterCost(pkg *qcBooking.Package, numberOfNodes int, additionalDisk uint32, storageTierType string) clusterCostEstimate {
+	nodePrice := int64(pkg.GetUnitIntPricePerHour())
+	if additionalDisk > 0 {
+		nodePrice += int64(additionalDisk) * int64(pkg.GetAvailableAdditionalResources().GetDiskPricePerHour())
+	}
+	if storageTierType != "" {
+		for _, c := range pkg.GetAvailableStorageTierConfigurations() {
+			if c.GetStorageTierType().String() == storageTierType {
+				nodePrice += int64(c.GetPricePerHour())
+				break
+			}
+		}
+	}
+	pricePerHour := float64(nodePrice*int64(numberOfNodes)) / bookingPriceUnitsPerCurrencyUnit
+	return clusterCostEstimate{
+		PricePerHour: roundPrice(pricePerHour, 5),
+		MonthlyCost:  roundPrice(pricePerHour*hoursPerMonth, 2),
+		Currency:     pkg.GetCurrency(),
+	}
+}
+
+// roundPrice rounds the provided price to the given amount of decimals.
+func roundPrice(v float64, decimals int) float64 {
+	p := math.Pow10(decimals)
+	return math.Round(v*p) / p
+}
+
+// flattenClusterCostEstimate creates a map from a cluster cost estimate for easy storage in Terraform.
+func flattenClusterCostEstimate(estimate clusterCostEstimate) map[string]interface{} {
+	return map[string]interface{}{
+		clusterEstimatedPricePerHourFieldName: estimate.PricePerHour,
+		clusterEstimatedMonthlyCostFieldName:  estimate.MonthlyCost,
+		clusterEstimatedCostCurrencyFieldName: estimate.Currency,
+	}
+}
+
+// lookupClusterCostEstimate estimates the cost of the provided cluster using the (cached) booking catalog.
+func lookupClusterCostEstimate(ctx context.Context, m interface{}, accountID string, cluster *qcCluster.Cluster) (clusterCostEstimate, bool, error) {
+	config := cluster.GetConfiguration()
+	return lookupCostEstimate(ctx, m, accountID, cluster.GetCloudProviderId(), cluster.GetCloudProviderRegionId(),
+		config.GetPackageId(), int(config.GetNumberOfNodes()), config.GetAdditionalResources().GetDisk(),
+		storageTierTypeString(config.GetClusterStorageConfiguration()))
+}
+
+// lookupCostEstimate estimates the cost of a cluster with the provided configuration using the (cached) booking catalog.
+// Hybrid cloud clusters are not part of the catalog, for those false is returned.
+func lookupCostEstimate(ctx context.Context, m interface{}, accountID, cloudProvider, cloudRegion, packageID string, numberOfNodes int, additionalDisk uint32, storageTierType string) (clusterCostEstimate, bool, error) {
+	if cloudProvider == hybridCloudClusterID || packageID == "" {
+		return clusterCostEstimate{}, false, nil
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, qcBooking.NewBookingServiceClient)
+	if diags.HasError() {
+		return clusterCostEstimate{}, false, fmt.Errorf("%s", diags[0].Summary)
+	}
+	packages, err := getBookingPackageCache(m).listPackages(clientCtx, client, accountID, cloudProvider, cloudRegion)
+	if err != nil {
+		return clusterCostEstimate{}, false, err
+	}
+	pkg := findBookingPackage(packages, packageID)
+	if pkg == nil {
+		return clusterCostEstimate{}, false, fmt.Errorf("package %q is not offered in %s/%s", packageID, cloudProvider, cloudRegion)
+	}
+	return estimateClusterCost(pkg, numberOfNodes, additionalDisk, storageTierType), true, nil
+}
+
+// storageTierTypeString returns the storage tier type of the provided storage configuration (or empty if unspecified).
+func storageTierTypeString(config *qcCluster.ClusterStorageConfiguration) string {
+	if config == nil || config.GetStorageTierType() == 0 {
+		return ""
+	}
+	return config.GetStorageTierType().String()
+}
+
+// setClusterCostEstimate sets the estimated cost of the cluster during plan, so cost deltas are visible in the plan output.
+// The estimate is only calculated during plan (it isn't refreshed on read), when the priced configuration changes
+// or no estimate is stored yet (e.g. after an import). In the latter case it is best-effort: if it cannot be calculated the state is kept.
+// Otherwise, if the estimate cannot be calculated (e.g. unknown values or the catalog is not available) the cost is known after apply.
+func setClusterCostEstimate(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	configPrefix := fmt.Sprintf("%s.0.", configurationFieldName)
+	nodeConfigPrefix := configPrefix + nodeConfigurationFieldName + ".0."
+	numberOfNodesPath := configPrefix + numberOfNodesFieldName
+	packageIDPath := nodeConfigPrefix + packageIDFieldName
+	resourceConfigurationsPath := nodeConfigPrefix + resourceConfigurationsFieldName
+	storageTierTypePath := fmt.Sprintf("%s%s.0.%s", configPrefix, clusterStorageConfigurationFieldName, clusterStorageTierTypeFieldName)
+	paths := []string{clusterCloudProviderFieldName, clusterCloudRegionFieldName, numberOfNodesPath, packageIDPath, resourceConfigurationsPath, storageTierTypePath}
+
+	changed := d.Id() == "" || d.HasChanges(paths...)
+	if !changed && d.Get(clusterEstimatedCostCurrencyFieldName).(string) != "" {
+		return nil
+	}
+	setComputed := func() error {
+		if !changed {
+			// Best-effort estimate of an unchanged cluster, keep the state.
+			return nil
+		}
+		for _, k := range []string{clusterEstimatedPricePerHourFieldName, clusterEstimatedMonthlyCostFieldName, clusterEstimatedCostCurrencyFieldName} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, k := range paths {
+		if !d.NewValueKnown(k) {
+			return setComputed()
+		}
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return setComputed()
+	}
+	resourceConfigurations, _ := d.Get(resourceConfigurationsPath).([]interface{})
+	estimate, ok, err := lookupCostEstimate(ctx, m, accountUUID.String(),
+		d.Get(clusterCloudProviderFieldName).(string), d.Get(clusterCloudRegionFieldName).(string),
+		d.Get(packageIDPath).(string), d.Get(numberOfNodesPath).(int),
+		expandClusterNodeResourceConfigurationsToAdditionalResources(resourceConfigurations).GetDisk(),
+		d.Get(storageTierTypePath).(string))
+	if err != nil {
+		return setComputed()
+	}
+	if !ok {
+		// Hybrid cloud clusters are not priced by the catalog.
+		estimate = clusterCostEstimate{}
+	}
+	for k, v := range flattenClusterCostEstimate(estimate) {
+		if err := d.SetNew(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package qdrant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+func newCostTestPackage() *qcBooking.Package {
+	return &qcBooking.Package{
+		Id:                  "pkg-1",
+		Name:                "gpx1",
+		Currency:            "usd",
+		UnitIntPricePerHour: 10000,
+		Status:              qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE,
+		AvailableAdditionalResources: &qcBooking.AvailableAdditionalResources{
+			DiskPricePerHour: 50,
+		},
+		AvailableStorageTierConfigurations: []*qcBooking.AvailableStoragePerformanceTierConfigurations{
+			{StorageTierType: commonv1.StorageTierType_STORAGE_TIER_TYPE_BALANCED, PricePerHour: 2000},
+		},
+	}
+}
+
+func TestEstimateClusterCost(t *testing.T) {
+	pkg := newCostTestPackage()
+
+	// Package only
+	estimate := estimateClusterCost(pkg, 1, 0, "")
+	assert.Equal(t, clusterCostEstimate{PricePerHour: 0.1, MonthlyCost: 73, Currency: "usd"}, estimate)
+
+	// 3 nodes with 100Gi additional disk and a storage tier: (10000 + 100*50 + 2000) * 3 = 51000
+	estimate = estimateClusterCost(pkg, 3, 100, "STORAGE_TIER_TYPE_BALANCED")
+	assert.Equal(t, clusterCostEstimate{PricePerHour: 0.51, MonthlyCost: 372.3, Currency: "usd"}, estimate)
+
+	// Storage tiers which are not offered are not priced
+	estimate = estimateClusterCost(pkg, 1, 0, "STORAGE_TIER_TYPE_PERFORMANCE")
+	assert.Equal(t, 0.1, estimate.PricePerHour)
+}
+
+func TestRoundPrice(t *testing.T) {
+	assert.Equal(t, 1.23, roundPrice(1.23456, 2))
+	assert.Equal(t, 1.23457, roundPrice(1.234567, 5))
+}
+
+func TestFlattenClusterCostEstimate(t *testing.T) {
+	expected := map[string]interface{}{
+		clusterEstimatedPricePerHourFieldName: 0.51,
+		clusterEstimatedMonthlyCostFieldName:  372.3,
+		clusterEstimatedCostCurrencyFieldName: "usd",
+	}
+	assert.Equal(t, expected, flattenClusterCostEstimate(clusterCostEstimate{PricePerHour: 0.51, MonthlyCost: 372.3, Currency: "usd"}))
+}
+
+func TestLookupCostEstimate_Hybrid(t *testing.T) {
+	estimate, ok, err := lookupCostEstimate(context.Background(), nil, "account-1", hybridCloudClusterID, "env-1", "pkg-1", 1, 0, "")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, clusterCostEstimate{}, estimate)
+}
+
+func TestStorageTierTypeString(t *testing.T) {
+	assert.Equal(t, "", storageTierTypeString(nil))
+	assert.Equal(t, "", storageTierTypeString(&qcCluster.ClusterStorageConfiguration{}))
+	assert.Equal(t, "STORAGE_TIER_TYPE_BALANCED", storageTierTypeString(&qcCluster.ClusterStorageConfiguration{
+		StorageTierType: commonv1.StorageTierType_STORAGE_TIER_TYPE_BALANCED,
+	}))
+}
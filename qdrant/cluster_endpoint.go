@@ -0,0 +1,198 @@
+package qdrant
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+const (
+	// defaultClusterRestPort is the REST port of a Qdrant cluster, if not reported by the API.
+	defaultClusterRestPort = 6333
+	// defaultClusterGrpcPort is the gRPC port of a Qdrant cluster, if not reported by the API.
+	defaultClusterGrpcPort = 6334
+	// clusterAPIKeyEnvVar is the environment variable the connection snippets read the API key from.
+	clusterAPIKeyEnvVar = "QDRANT_API_KEY"
+)
+
+// clusterEndpoint contains the connection details of a cluster.
+type clusterEndpoint struct {
+	Scheme   string
+	Host     string
+	RestPort int
+	GrpcPort int
+}
+
+// accountsClusterEndpointSchema defines the schema for the (computed) endpoint of a cluster.
+func accountsClusterEndpointSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		clusterEndpointHostFieldName: {
+			Description: "The host name of the cluster",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		clusterEndpointRestPortFieldName: {
+			Description: "The port of the REST API of the cluster",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		clusterEndpointGrpcPortFieldName: {
+			Description: "The port of the gRPC API of the cluster",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		clusterEndpointRestURLFieldName: {
+			Description: "The URL of the REST API of the cluster (including the port)",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		clusterEndpointGrpcURLFieldName: {
+			Description: "The URL of the gRPC API of the cluster (including the port)",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+// accountsClusterConnectionSnippetsSchema defines the schema for the (computed) connection snippets of a cluster.
+func accountsClusterConnectionSnippetsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		clusterConnectionSnippetPythonFieldName: {
+			Description: "Snippet to connect to the cluster using the Python client (qdrant-client)",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		clusterConnectionSnippetGoFieldName: {
+			Description: "Snippet to connect to the cluster using the Go client (github.com/qdrant/go-client)",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		clusterConnectionSnippetJavaScriptFieldName: {
+			Description: "Snippet to connect to the cluster using the JavaScript client (@qdrant/js-client-rest)",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+	}
+}
+
+// newClusterEndpoint returns the connection details of the provided cluster endpoint.
+// The scheme defaults to https, the ports default to the ports in the URL or the Qdrant defaults (6333 and 6334).
+// False is returned if the endpoint doesn't have a (valid) URL (e.g. the cluster is still being created).
+func newClusterEndpoint(endpoint *qcCluster.ClusterEndpoint) (clusterEndpoint, bool) {
+	raw := strings.TrimSpace(endpoint.GetUrl())
+	if raw == "" {
+		return clusterEndpoint{}, false
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return clusterEndpoint{}, false
+	}
+	result := clusterEndpoint{
+		Scheme:   u.Scheme,
+		Host:     u.Hostname(),
+		RestPort: int(endpoint.GetRestPort()),
+		GrpcPort: int(endpoint.GetGrpcPort()),
+	}
+	if result.RestPort == 0 {
+		result.RestPort = defaultClusterRestPort
+		if port, err := strconv.Atoi(u.Port()); err == nil {
+			result.RestPort = port
+		}
+	}
+	if result.GrpcPort == 0 {
+		result.GrpcPort = defaultClusterGrpcPort
+	}
+	return result, true
+}
+
+// RestURL returns the URL of the REST API.
+func (e clusterEndpoint) RestURL() string {
+	return fmt.Sprintf("%s://%s", e.Scheme, net.JoinHostPort(e.Host, strconv.Itoa(e.RestPort)))
+}
+
+// GrpcURL returns the URL of the gRPC API.
+func (e clusterEndpoint) GrpcURL() string {
+	return fmt.Sprintf("%s://%s", e.Scheme, net.JoinHostPort(e.Host, strconv.Itoa(e.GrpcPort)))
+}
+
+// UseTLS returns true if the endpoint should be accessed using TLS.
+func (e clusterEndpoint) UseTLS() bool {
+	return e.Scheme == "https"
+}
+
+// flattenClusterEndpoint creates an interface from the cluster endpoint for easy storage in Terraform.
+func flattenClusterEndpoint(endpoint *qcCluster.ClusterEndpoint) []interface{} {
+	e, ok := newClusterEndpoint(endpoint)
+	if !ok {
+		return []interface{}{}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			clusterEndpointHostFieldName:     e.Host,
+			clusterEndpointRestPortFieldName: e.RestPort,
+			clusterEndpointGrpcPortFieldName: e.GrpcPort,
+			clusterEndpointRestURLFieldName:  e.RestURL(),
+			clusterEndpointGrpcURLFieldName:  e.GrpcURL(),
+		},
+	}
+}
+
+// flattenClusterConnectionSnippets creates an interface from the connection snippets of the cluster endpoint for easy storage in Terraform.
+// The snippets read the API key from the QDRANT_API_KEY environment variable, so no secrets are rendered.
+func flattenClusterConnectionSnippets(endpoint *qcCluster.ClusterEndpoint) []interface{} {
+	e, ok := newClusterEndpoint(endpoint)
+	if !ok {
+		return []interface{}{}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			clusterConnectionSnippetPythonFieldName:     renderPythonConnectionSnippet(e),
+			clusterConnectionSnippetGoFieldName:         renderGoConnectionSnippet(e),
+			clusterConnectionSnippetJavaScriptFieldName: renderJavaScriptConnectionSnippet(e),
+		},
+	}
+}
+
+// renderPythonConnectionSnippet renders a snippet to connect to the cluster using the Python client.
+func renderPythonConnectionSnippet(e clusterEndpoint) string {
+	return fmt.Sprintf(`import os
+
+from qdrant_client import QdrantClient
+
+client = QdrantClient(
+    url=%q,
+    api_key=os.environ[%q],
+)
+`, e.RestURL(), clusterAPIKeyEnvVar)
+}
+
+// renderGoConnectionSnippet renders a snippet to connect to the cluster using the Go client (which uses gRPC).
+func renderGoConnectionSnippet(e clusterEndpoint) string {
+	return fmt.Sprintf(`client, err := qdrant.NewClient(&qdrant.Config{
+	Host:   %q,
+	Port:   %d,
+	APIKey: os.Getenv(%q),
+	UseTLS: %t,
+})
+`, e.Host, e.GrpcPort, clusterAPIKeyEnvVar, e.UseTLS())
+}
+
+// renderJavaScriptConnectionSnippet renders a snippet to connect to the cluster using the JavaScript client.
+func renderJavaScriptConnectionSnippet(e clusterEndpoint) string {
+	return fmt.Sprintf(`import { QdrantClient } from "@qdrant/js-client-rest";
+
+const client = new QdrantClient({
+  url: %q,
+  apiKey: process.env.%s,
+});
+`, e.RestURL(), clusterAPIKeyEnvVar)
+}
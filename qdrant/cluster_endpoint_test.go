@@ -0,0 +1,100 @@
+package qdrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+func TestNewClusterEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		endpoint *qcCluster.ClusterEndpoint
+		expected clusterEndpoint
+	}{
+		{
+			name:     "default ports",
+			endpoint: &qcCluster.ClusterEndpoint{Url: "https://abc.eu-central-1-0.aws.cloud.qdrant.io"},
+			expected: clusterEndpoint{Scheme: "https", Host: "abc.eu-central-1-0.aws.cloud.qdrant.io", RestPort: 6333, GrpcPort: 6334},
+		},
+		{
+			name:     "ports reported by the API",
+			endpoint: &qcCluster.ClusterEndpoint{Url: "https://abc.example.com", RestPort: 443, GrpcPort: 8443},
+			expected: clusterEndpoint{Scheme: "https", Host: "abc.example.com", RestPort: 443, GrpcPort: 8443},
+		},
+		{
+			name:     "port in URL",
+			endpoint: &qcCluster.ClusterEndpoint{Url: "http://qdrant.internal:7333"},
+			expected: clusterEndpoint{Scheme: "http", Host: "qdrant.internal", RestPort: 7333, GrpcPort: 6334},
+		},
+		{
+			name:     "no scheme",
+			endpoint: &qcCluster.ClusterEndpoint{Url: "abc.example.com"},
+			expected: clusterEndpoint{Scheme: "https", Host: "abc.example.com", RestPort: 6333, GrpcPort: 6334},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e, ok := newClusterEndpoint(tc.endpoint)
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, e)
+		})
+	}
+
+	_, ok := newClusterEndpoint(nil)
+	assert.False(t, ok)
+	_, ok = newClusterEndpoint(&qcCluster.ClusterEndpoint{Url: "https://"})
+	assert.False(t, ok)
+}
+
+func TestFlattenClusterEndpoint(t *testing.T) {
+	endpoint := &qcCluster.ClusterEndpoint{Url: "https://abc.example.com", RestPort: 6333, GrpcPort: 6334}
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			clusterEndpointHostFieldName:     "abc.example.com",
+			clusterEndpointRestPortFieldName: 6333,
+			clusterEndpointGrpcPortFieldName: 6334,
+			clusterEndpointRestURLFieldName:  "https://abc.example.com:6333",
+			clusterEndpointGrpcURLFieldName:  "https://abc.example.com:6334",
+		},
+	}, flattenClusterEndpoint(endpoint))
+	assert.Empty(t, flattenClusterEndpoint(nil))
+	assert.Empty(t, flattenClusterConnectionSnippets(nil))
+}
+
+func TestFlattenClusterConnectionSnippets(t *testing.T) {
+	endpoint := &qcCluster.ClusterEndpoint{Url: "https://abc.example.com"}
+	flattened := flattenClusterConnectionSnippets(endpoint)
+	require.Len(t, flattened, 1)
+	snippets := flattened[0].(map[string]interface{})
+
+	assert.Equal(t, `import os
+
+from qdrant_client import QdrantClient
+
+client = QdrantClient(
+    url="https://abc.example.com:6333",
+    api_key=os.environ["QDRANT_API_KEY"],
+)
+`, snippets[clusterConnectionSnippetPythonFieldName])
+	assert.Equal(t, `client, err := qdrant.NewClient(&qdrant.Config{
+	Host:   "abc.example.com",
+	Port:   6334,
+	APIKey: os.Getenv("QDRANT_API_KEY"),
+	UseTLS: true,
+})
+`, snippets[clusterConnectionSnippetGoFieldName])
+	assert.Equal(t, `import { QdrantClient } from "@qdrant/js-client-rest";
+
+const client = new QdrantClient({
+  url: "https://abc.example.com:6333",
+  apiKey: process.env.QDRANT_API_KEY,
+});
+`, snippets[clusterConnectionSnippetJavaScriptFieldName])
+
+	// Plain HTTP endpoints (e.g. hybrid cloud) don't use TLS
+	flattened = flattenClusterConnectionSnippets(&qcCluster.ClusterEndpoint{Url: "http://qdrant.internal"})
+	assert.Contains(t, flattened[0].(map[string]interface{})[clusterConnectionSnippetGoFieldName], "UseTLS: false")
+}
@@ -0,0 +1,101 @@
+package qdrant
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+// clusterFilter contains the (optional) filters of the clusters list data source.
+// Empty filters match all clusters.
+type clusterFilter struct {
+	LabelSelector []labelRequirement
+	NameRegex     *regexp.Regexp
+	CloudProvider string
+	CloudRegion   string
+	Phase         string
+	Version       string
+}
+
+// expandClusterFilter returns the cluster filter of the provided configuration.
+func expandClusterFilter(d *schema.ResourceData) (clusterFilter, error) {
+	var filter clusterFilter
+	var err error
+	if filter.LabelSelector, err = parseLabelSelector(d.Get(clustersLabelSelectorFieldName).(string)); err != nil {
+		return filter, err
+	}
+	if v := d.Get(clustersNameRegexFieldName).(string); v != "" {
+		if filter.NameRegex, err = regexp.Compile(v); err != nil {
+			return filter, fmt.Errorf("invalid %s %q: %w", clustersNameRegexFieldName, v, err)
+		}
+	}
+	filter.CloudProvider = d.Get(clustersCloudProviderFieldName).(string)
+	filter.CloudRegion = d.Get(clustersCloudRegionFieldName).(string)
+	filter.Phase = d.Get(clustersPhaseFieldName).(string)
+	filter.Version = d.Get(clustersVersionFieldName).(string)
+	return filter, nil
+}
+
+// matches returns true if the cluster meets all filters.
+func (f clusterFilter) matches(cluster *qcCluster.Cluster) bool {
+	if f.CloudProvider != "" && cluster.GetCloudProviderId() != f.CloudProvider {
+		return false
+	}
+	if f.CloudRegion != "" && cluster.GetCloudProviderRegionId() != f.CloudRegion {
+		return false
+	}
+	if f.Phase != "" && cluster.GetState().GetPhase().String() != f.Phase {
+		return false
+	}
+	if f.NameRegex != nil && !f.NameRegex.MatchString(cluster.GetName()) {
+		return false
+	}
+	if f.Version != "" && !matchQdrantVersion(f.Version, clusterRunningVersion(cluster)) {
+		return false
+	}
+	return matchLabelSelector(f.LabelSelector, cluster.GetLabels())
+}
+
+// filterClusters returns the clusters meeting all filters, in the original order.
+func filterClusters(clusters []*qcCluster.Cluster, filter clusterFilter) []*qcCluster.Cluster {
+	var result []*qcCluster.Cluster
+	for _, cluster := range clusters {
+		if filter.matches(cluster) {
+			result = append(result, cluster)
+		}
+	}
+	return result
+}
+
+// clusterRunningVersion returns the version the cluster is running,
+// or the configured version if the cluster doesn't report one (yet).
+func clusterRunningVersion(cluster *qcCluster.Cluster) string {
+	if v := cluster.GetState().GetVersion(); v != "" {
+		return v
+	}
+	return cluster.GetConfiguration().GetVersion()
+}
+
+// matchQdrantVersion returns true if the version matches the filter, only the components
+// of the filter are compared (e.g. "v1.13" matches "v1.13.4", while "v1.13.0" only matches "v1.13.0").
+func matchQdrantVersion(filter, version string) bool {
+	f, err := parseQdrantVersion(filter)
+	if err != nil {
+		return false
+	}
+	v, err := parseQdrantVersion(version)
+	if err != nil {
+		return false
+	}
+	components := strings.Count(strings.Trim(strings.TrimSpace(filter), "v"), ".") + 1
+	for i := 0; i < components && i < len(f); i++ {
+		if f[i] != v[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package qdrant
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+func newFilterTestClusters() []*qcCluster.Cluster {
+	return []*qcCluster.Cluster{
+		{
+			Id: "c1", Name: "prod-search", CloudProviderId: "aws", CloudProviderRegionId: "us-east-1",
+			Labels: []*commonv1.KeyValue{{Key: "env", Value: "prod"}, {Key: "team", Value: "search"}},
+			State:  &qcCluster.ClusterState{Version: "v1.13.4", Phase: qcCluster.ClusterPhase_CLUSTER_PHASE_HEALTHY},
+		},
+		{
+			Id: "c2", Name: "prod-recommend", CloudProviderId: "gcp", CloudProviderRegionId: "europe-west3",
+			Labels: []*commonv1.KeyValue{{Key: "env", Value: "prod"}, {Key: "team", Value: "recommend"}},
+			State:  &qcCluster.ClusterState{Version: "v1.12.6", Phase: qcCluster.ClusterPhase_CLUSTER_PHASE_HEALTHY},
+		},
+		{
+			Id: "c3", Name: "dev-search", CloudProviderId: "aws", CloudProviderRegionId: "us-east-1",
+			Labels:        []*commonv1.KeyValue{{Key: "env", Value: "dev"}},
+			Configuration: &qcCluster.ClusterConfiguration{Version: newPointer("v1.13.0")},
+		},
+	}
+}
+
+func TestFilterClusters(t *testing.T) {
+	clusters := newFilterTestClusters()
+	selector := func(s string) []labelRequirement {
+		reqs, err := parseLabelSelector(s)
+		require.NoError(t, err)
+		return reqs
+	}
+
+	assert.Equal(t, []string{"c1", "c2", "c3"}, clusterIDs(filterClusters(clusters, clusterFilter{})))
+	assert.Equal(t, []string{"c1", "c2"}, clusterIDs(filterClusters(clusters, clusterFilter{LabelSelector: selector("env=prod")})))
+	assert.Equal(t, []string{"c1", "c3"}, clusterIDs(filterClusters(clusters, clusterFilter{LabelSelector: selector("team notin (recommend)")})))
+	assert.Equal(t, []string{"c1", "c3"}, clusterIDs(filterClusters(clusters, clusterFilter{CloudProvider: "aws"})))
+	assert.Equal(t, []string{"c2"}, clusterIDs(filterClusters(clusters, clusterFilter{CloudRegion: "europe-west3"})))
+	assert.Equal(t, []string{"c1", "c2"}, clusterIDs(filterClusters(clusters, clusterFilter{Phase: "CLUSTER_PHASE_HEALTHY"})))
+	assert.Equal(t, []string{"c1", "c3"}, clusterIDs(filterClusters(clusters, clusterFilter{Version: "v1.13"})))
+	assert.Equal(t, []string{"c3"}, clusterIDs(filterClusters(clusters, clusterFilter{Version: "1.13.0"})))
+
+	filter := clusterFilter{NameRegex: regexp.MustCompile("search$"), LabelSelector: selector("env=prod")}
+	assert.Equal(t, []string{"c1"}, clusterIDs(filterClusters(clusters, filter)))
+	assert.Empty(t, filterClusters(clusters, clusterFilter{CloudProvider: "azure"}))
+}
+
+func TestMatchQdrantVersion(t *testing.T) {
+	assert.True(t, matchQdrantVersion("v1", "v1.13.4"))
+	assert.True(t, matchQdrantVersion("v1.13", "v1.13.4"))
+	assert.True(t, matchQdrantVersion("1.13.4", "v1.13.4"))
+	assert.False(t, matchQdrantVersion("v1.13.0", "v1.13.4"))
+	assert.False(t, matchQdrantVersion("v1.12", "v1.13.4"))
+	assert.False(t, matchQdrantVersion("v1.13", ""))
+}
+
+func TestExpandClusterFilter(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, accountsClustersSchema(), map[string]interface{}{
+		clustersLabelSelectorFieldName: "env=prod,team in (a,b)",
+		clustersNameRegexFieldName:     "^prod-",
+		clustersCloudProviderFieldName: "aws",
+		clustersCloudRegionFieldName:   "us-east-1",
+		clustersPhaseFieldName:         "CLUSTER_PHASE_HEALTHY",
+		clustersVersionFieldName:       "v1.13",
+	})
+	filter, err := expandClusterFilter(d)
+	require.NoError(t, err)
+	assert.Len(t, filter.LabelSelector, 2)
+	assert.Equal(t, "^prod-", filter.NameRegex.String())
+	assert.Equal(t, "aws", filter.CloudProvider)
+	assert.Equal(t, "us-east-1", filter.CloudRegion)
+	assert.Equal(t, "CLUSTER_PHASE_HEALTHY", filter.Phase)
+	assert.Equal(t, "v1.13", filter.Version)
+
+	filter, err = expandClusterFilter(schema.TestResourceDataRaw(t, accountsClustersSchema(), map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.Equal(t, clusterFilter{}, filter)
+}
@@ -0,0 +1,219 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qcb "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+// hybridClusterStorage contains the storage settings of a hybrid cluster, which are validated against
+// the storage classes discovered in its hybrid cloud environment.
+type hybridClusterStorage struct {
+	DatabaseStorageClass string
+	SnapshotStorageClass string
+	VolumeSnapshotClass  string
+	// DiskExpansion is set if the (additional) disk of the nodes grows.
+	DiskExpansion bool
+	// BackupsConfigured is set if backups are taken by the provider (final_backup or backup_before_update),
+	// or if an existing cluster has a backup schedule (schedules of new clusters cannot be known during plan).
+	BackupsConfigured bool
+}
+
+// validateHybridClusterStorage validates the storage settings of a hybrid cluster against the storage classes
+// and volume snapshot classes reported by the status of the environment.
+// Environments which didn't report any classes yet (e.g. not bootstrapped) are not validated, so configured backups
+// are only rejected if the environment reports storage classes, but no (and has no default) volume snapshot class.
+func validateHybridClusterStorage(env *qch.HybridCloudEnvironment, storage hybridClusterStorage) error {
+	st := env.GetStatus()
+	storageClasses := st.GetStorageClasses()
+	snapshotClasses := st.GetVolumeSnapshotClasses()
+	if len(storageClasses) == 0 && len(snapshotClasses) == 0 {
+		return nil
+	}
+	storagePrefix := fmt.Sprintf("%s.0.%s.0.", configurationFieldName, clusterStorageConfigurationFieldName)
+
+	if len(storageClasses) > 0 {
+		names := make([]string, 0, len(storageClasses))
+		for _, sc := range storageClasses {
+			names = append(names, sc.GetName())
+		}
+		sort.Strings(names)
+		for _, c := range []struct {
+			field string
+			value string
+		}{
+			{clusterDatabaseStorageClassFieldName, storage.DatabaseStorageClass},
+			{clusterSnapshotStorageClassFieldName, storage.SnapshotStorageClass},
+		} {
+			if c.value != "" && findHCEnvStorageClass(storageClasses, c.value) == nil {
+				return fmt.Errorf("%s%s: storage class %q is not available in hybrid cloud environment %s, must be one of: %s",
+					storagePrefix, c.field, c.value, env.GetId(), strings.Join(names, ", "))
+			}
+		}
+	}
+	if storage.VolumeSnapshotClass != "" && !hasHCEnvVolumeSnapshotClass(snapshotClasses, storage.VolumeSnapshotClass) {
+		names := make([]string, 0, len(snapshotClasses))
+		for _, vsc := range snapshotClasses {
+			names = append(names, vsc.GetName())
+		}
+		sort.Strings(names)
+		available := strings.Join(names, ", ")
+		if available == "" {
+			available = "none"
+		}
+		return fmt.Errorf("%s%s: volume snapshot class %q is not available in hybrid cloud environment %s, available: %s",
+			storagePrefix, clusterVolumeSnapshotClassFieldName, storage.VolumeSnapshotClass, env.GetId(), available)
+	}
+	if storage.DiskExpansion {
+		if sc := effectiveDatabaseStorageClass(env, storage.DatabaseStorageClass); sc != nil && !sc.GetAllowVolumeExpansion() {
+			return fmt.Errorf("%s%s: storage class %q of hybrid cloud environment %s does not allow volume expansion, the disk of the nodes cannot grow",
+				storagePrefix, clusterDatabaseStorageClassFieldName, sc.GetName(), env.GetId())
+		}
+	}
+	if storage.BackupsConfigured && storage.VolumeSnapshotClass == "" &&
+		env.GetConfiguration().GetVolumeSnapshotStorageClass() == "" && len(snapshotClasses) == 0 {
+		return fmt.Errorf("%s%s: backups are configured, but hybrid cloud environment %s has no volume snapshot class",
+			storagePrefix, clusterVolumeSnapshotClassFieldName, env.GetId())
+	}
+	return nil
+}
+
+// findHCEnvStorageClass returns the storage class with the provided name (or nil if not found).
+func findHCEnvStorageClass(classes []*qch.HybridCloudEnvironmentStorageClass, name string) *qch.HybridCloudEnvironmentStorageClass {
+	for _, sc := range classes {
+		if sc.GetName() == name {
+			return sc
+		}
+	}
+	return nil
+}
+
+// hasHCEnvVolumeSnapshotClass returns true if the volume snapshot class with the provided name exists.
+func hasHCEnvVolumeSnapshotClass(classes []*qch.HybridCloudEnvironmentVolumeSnapshotClass, name string) bool {
+	for _, vsc := range classes {
+		if vsc.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveDatabaseStorageClass returns the storage class used for the database storage, which is the configured class,
+// the default of the environment configuration, or the default storage class of the Kubernetes cluster (in that order).
+func effectiveDatabaseStorageClass(env *qch.HybridCloudEnvironment, configured string) *qch.HybridCloudEnvironmentStorageClass {
+	classes := env.GetStatus().GetStorageClasses()
+	for _, name := range []string{configured, env.GetConfiguration().GetDatabaseStorageClass()} {
+		if name != "" {
+			return findHCEnvStorageClass(classes, name)
+		}
+	}
+	for _, sc := range classes {
+		if sc.GetDefault() {
+			return sc
+		}
+	}
+	return nil
+}
+
+// validateClusterHybridStorage validates the storage settings of a hybrid cluster against the storage classes
+// discovered in its hybrid cloud environment, so invalid settings are reported during plan instead of apply.
+// Existing clusters are only validated if one of the related fields changes.
+func validateClusterHybridStorage(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	storagePrefix := fmt.Sprintf("%s.0.%s.0.", configurationFieldName, clusterStorageConfigurationFieldName)
+	resourceConfigurationsPath := fmt.Sprintf("%s.0.%s.0.%s", configurationFieldName, nodeConfigurationFieldName, resourceConfigurationsFieldName)
+	databaseStorageClassPath := storagePrefix + clusterDatabaseStorageClassFieldName
+	snapshotStorageClassPath := storagePrefix + clusterSnapshotStorageClassFieldName
+	volumeSnapshotClassPath := storagePrefix + clusterVolumeSnapshotClassFieldName
+
+	if d.Id() != "" && !d.HasChanges(clusterCloudProviderFieldName, clusterCloudRegionFieldName, databaseStorageClassPath, snapshotStorageClassPath,
+		volumeSnapshotClassPath, resourceConfigurationsPath, clusterFinalBackupFieldName, clusterBackupBeforeUpdateFieldName) {
+		return nil
+	}
+	for _, k := range []string{clusterCloudProviderFieldName, clusterCloudRegionFieldName} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+	if d.Get(clusterCloudProviderFieldName).(string) != hybridCloudClusterID {
+		return nil
+	}
+	// If the environment cannot be fetched, the cluster is validated by the API during apply.
+	envID := d.Get(clusterCloudRegionFieldName).(string)
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		tflog.Debug(ctx, "Skipping the storage validation of the hybrid cluster, the account is unknown", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, qch.NewHybridCloudServiceClient)
+	if diags.HasError() {
+		tflog.Debug(ctx, "Skipping the storage validation of the hybrid cluster, no client available", map[string]interface{}{"error": diags[0].Summary})
+		return nil
+	}
+	resp, err := client.GetHybridCloudEnvironment(clientCtx, &qch.GetHybridCloudEnvironmentRequest{
+		AccountId:                accountUUID.String(),
+		HybridCloudEnvironmentId: envID,
+	})
+	if err != nil {
+		tflog.Debug(ctx, "Skipping the storage validation of the hybrid cluster, the environment cannot be fetched",
+			map[string]interface{}{"hybrid_cloud_environment_id": envID, "error": err.Error()})
+		return nil
+	}
+
+	knownString := func(k string) string {
+		if !d.NewValueKnown(k) {
+			return ""
+		}
+		v, _ := d.Get(k).(string)
+		return v
+	}
+	storage := hybridClusterStorage{
+		DatabaseStorageClass: knownString(databaseStorageClassPath),
+		SnapshotStorageClass: knownString(snapshotStorageClassPath),
+		VolumeSnapshotClass:  knownString(volumeSnapshotClassPath),
+	}
+	if d.Id() != "" && d.HasChange(resourceConfigurationsPath) {
+		o, n := d.GetChange(resourceConfigurationsPath)
+		storage.DiskExpansion = expandClusterNodeResourceConfigurationsToAdditionalResources(toInterfaceList(n)).GetDisk() >
+			expandClusterNodeResourceConfigurationsToAdditionalResources(toInterfaceList(o)).GetDisk()
+	}
+	for _, k := range []string{clusterFinalBackupFieldName, clusterBackupBeforeUpdateFieldName} {
+		if enabled, _ := d.Get(fmt.Sprintf("%s.0.%s", k, clusterBackupSettingsEnabledFieldName)).(bool); enabled {
+			storage.BackupsConfigured = true
+		}
+	}
+	if !storage.BackupsConfigured && d.Id() != "" {
+		backupClient, backupClientCtx, diags := getServiceClient(ctx, m, qcb.NewBackupServiceClient)
+		if diags.HasError() {
+			tflog.Debug(ctx, "Cannot list the backup schedules of the hybrid cluster, no client available", map[string]interface{}{"error": diags[0].Summary})
+		} else {
+			storage.BackupsConfigured = hasClusterBackupSchedules(backupClientCtx, backupClient, accountUUID.String(), d.Id())
+		}
+	}
+	return validateHybridClusterStorage(resp.GetHybridCloudEnvironment(), storage)
+}
+
+// hasClusterBackupSchedules returns true if the existing cluster has a backup schedule.
+// Errors are logged and reported as no schedule, as the API validates the cluster during apply anyway.
+func hasClusterBackupSchedules(ctx context.Context, client qcb.BackupServiceClient, accountID, clusterID string) bool {
+	var trailer metadata.MD
+	resp, err := client.ListBackupSchedules(ctx, &qcb.ListBackupSchedulesRequest{
+		AccountId: accountID,
+		ClusterId: newPointer(clusterID),
+	}, grpc.Trailer(&trailer))
+	if err != nil {
+		tflog.Debug(ctx, "Cannot list the backup schedules of the hybrid cluster", map[string]interface{}{
+			"cluster_id": clusterID, "error": fmt.Sprintf("%s%s", err, getRequestID(trailer)),
+		})
+		return false
+	}
+	return len(resp.GetItems()) > 0
+}
@@ -0,0 +1,114 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	qcb "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+type mockBackupScheduleListClient struct {
+	qcb.BackupServiceClient
+	schedules []*qcb.BackupSchedule
+	err       error
+}
+
+func (m *mockBackupScheduleListClient) ListBackupSchedules(_ context.Context, _ *qcb.ListBackupSchedulesRequest, _ ...grpc.CallOption) (*qcb.ListBackupSchedulesResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &qcb.ListBackupSchedulesResponse{Items: m.schedules}, nil
+}
+
+func newStorageTestHCEnv() *qch.HybridCloudEnvironment {
+	return &qch.HybridCloudEnvironment{
+		Id:            "env-1",
+		Configuration: &qch.HybridCloudEnvironmentConfiguration{},
+		Status: &qch.HybridCloudEnvironmentStatus{
+			StorageClasses: []*qch.HybridCloudEnvironmentStorageClass{
+				{Name: "standard", Default: true, AllowVolumeExpansion: false},
+				{Name: "fast-ssd", AllowVolumeExpansion: true},
+			},
+			VolumeSnapshotClasses: []*qch.HybridCloudEnvironmentVolumeSnapshotClass{
+				{Name: "csi-snapclass", Driver: "ebs.csi.aws.com"},
+			},
+		},
+	}
+}
+
+func TestValidateHybridClusterStorage(t *testing.T) {
+	env := newStorageTestHCEnv()
+
+	assert.NoError(t, validateHybridClusterStorage(env, hybridClusterStorage{}))
+	assert.NoError(t, validateHybridClusterStorage(env, hybridClusterStorage{
+		DatabaseStorageClass: "fast-ssd",
+		SnapshotStorageClass: "standard",
+		VolumeSnapshotClass:  "csi-snapclass",
+		DiskExpansion:        true,
+		BackupsConfigured:    true,
+	}))
+
+	err := validateHybridClusterStorage(env, hybridClusterStorage{DatabaseStorageClass: "gp3"})
+	assert.EqualError(t, err, `configuration.0.cluster_storage_configuration.0.database_storage_class: storage class "gp3" is not available in hybrid cloud environment env-1, must be one of: fast-ssd, standard`)
+
+	err = validateHybridClusterStorage(env, hybridClusterStorage{SnapshotStorageClass: "gp3"})
+	assert.ErrorContains(t, err, `snapshot_storage_class: storage class "gp3" is not available`)
+
+	err = validateHybridClusterStorage(env, hybridClusterStorage{VolumeSnapshotClass: "other"})
+	assert.EqualError(t, err, `configuration.0.cluster_storage_configuration.0.volume_snapshot_class: volume snapshot class "other" is not available in hybrid cloud environment env-1, available: csi-snapclass`)
+}
+
+func TestValidateHybridClusterStorageDiskExpansion(t *testing.T) {
+	env := newStorageTestHCEnv()
+
+	// The default storage class doesn't allow expansion
+	err := validateHybridClusterStorage(env, hybridClusterStorage{DiskExpansion: true})
+	assert.EqualError(t, err, `configuration.0.cluster_storage_configuration.0.database_storage_class: storage class "standard" of hybrid cloud environment env-1 does not allow volume expansion, the disk of the nodes cannot grow`)
+
+	// The default of the environment configuration is used before the Kubernetes default
+	env.Configuration.DatabaseStorageClass = newPointer("fast-ssd")
+	assert.NoError(t, validateHybridClusterStorage(env, hybridClusterStorage{DiskExpansion: true}))
+
+	// The configured class of the cluster is used first
+	err = validateHybridClusterStorage(env, hybridClusterStorage{DatabaseStorageClass: "standard", DiskExpansion: true})
+	assert.ErrorContains(t, err, `storage class "standard" of hybrid cloud environment env-1 does not allow volume expansion`)
+}
+
+func TestValidateHybridClusterStorageBackups(t *testing.T) {
+	env := newStorageTestHCEnv()
+	env.Status.VolumeSnapshotClasses = nil
+
+	err := validateHybridClusterStorage(env, hybridClusterStorage{BackupsConfigured: true})
+	assert.EqualError(t, err, `configuration.0.cluster_storage_configuration.0.volume_snapshot_class: backups are configured, but hybrid cloud environment env-1 has no volume snapshot class`)
+
+	err = validateHybridClusterStorage(env, hybridClusterStorage{VolumeSnapshotClass: "csi-snapclass"})
+	assert.ErrorContains(t, err, `volume snapshot class "csi-snapclass" is not available in hybrid cloud environment env-1, available: none`)
+
+	// The default of the environment configuration is sufficient
+	env.Configuration.VolumeSnapshotStorageClass = newPointer("csi-snapclass")
+	assert.NoError(t, validateHybridClusterStorage(env, hybridClusterStorage{BackupsConfigured: true}))
+}
+
+func TestValidateHybridClusterStorageNotDiscovered(t *testing.T) {
+	// Environments which didn't report any classes yet are not validated
+	env := &qch.HybridCloudEnvironment{Id: "env-1"}
+	assert.NoError(t, validateHybridClusterStorage(env, hybridClusterStorage{
+		DatabaseStorageClass: "gp3",
+		VolumeSnapshotClass:  "other",
+		DiskExpansion:        true,
+		BackupsConfigured:    true,
+	}))
+}
+
+func TestHasClusterBackupSchedules(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, hasClusterBackupSchedules(ctx, &mockBackupScheduleListClient{schedules: []*qcb.BackupSchedule{{Id: "schedule-1"}}}, "acc-1", "cluster-1"))
+	assert.False(t, hasClusterBackupSchedules(ctx, &mockBackupScheduleListClient{}, "acc-1", "cluster-1"))
+	// Errors are reported as no schedule, the API validates the cluster during apply
+	assert.False(t, hasClusterBackupSchedules(ctx, &mockBackupScheduleListClient{err: errors.New("unavailable")}, "acc-1", "cluster-1"))
+}
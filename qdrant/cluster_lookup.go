@@ -0,0 +1,126 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+// clusterImportNamePrefix is the prefix of an import ID which refers to a cluster by name (e.g. "name:prod-search").
+const clusterImportNamePrefix = "name:"
+
+// listClusters returns all clusters of the provided account.
+func listClusters(ctx context.Context, client qcCluster.ClusterServiceClient, accountID string) ([]*qcCluster.Cluster, error) {
+	return listClustersWithRequest(ctx, client, &qcCluster.ListClustersRequest{AccountId: accountID})
+}
+
+// listClustersWithRequest returns the clusters matching the provided request.
+func listClustersWithRequest(ctx context.Context, client qcCluster.ClusterServiceClient, req *qcCluster.ListClustersRequest) ([]*qcCluster.Cluster, error) {
+	var trailer metadata.MD
+	resp, err := client.ListClusters(ctx, req, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, fmt.Errorf("error listing clusters%s: %w", getRequestID(trailer), err)
+	}
+	return resp.GetItems(), nil
+}
+
+// matchClusters returns the clusters with the provided name (if not empty) having all provided labels.
+func matchClusters(clusters []*qcCluster.Cluster, name string, labels []*commonv1.KeyValue) []*qcCluster.Cluster {
+	var result []*qcCluster.Cluster
+	for _, cluster := range clusters {
+		if name != "" && cluster.GetName() != name {
+			continue
+		}
+		if !hasAllLabels(cluster.GetLabels(), labels) {
+			continue
+		}
+		result = append(result, cluster)
+	}
+	return result
+}
+
+// hasAllLabels returns true if all wanted labels are part of the provided labels (with the same value).
+func hasAllLabels(labels, wanted []*commonv1.KeyValue) bool {
+	values := make(map[string]string, len(labels))
+	for _, kv := range labels {
+		values[kv.GetKey()] = kv.GetValue()
+	}
+	for _, kv := range wanted {
+		if v, ok := values[kv.GetKey()]; !ok || v != kv.GetValue() {
+			return false
+		}
+	}
+	return true
+}
+
+// describeClusterLookup returns a human readable description of the lookup (e.g. `name = "prod", labels = {env = "prod"}`).
+func describeClusterLookup(name string, labels []*commonv1.KeyValue) string {
+	var parts []string
+	if name != "" {
+		parts = append(parts, fmt.Sprintf("%s = %q", clusterNameFieldName, name))
+	}
+	if len(labels) > 0 {
+		items := make([]string, 0, len(labels))
+		for _, kv := range labels {
+			items = append(items, fmt.Sprintf("%s = %q", kv.GetKey(), kv.GetValue()))
+		}
+		sort.Strings(items)
+		parts = append(parts, fmt.Sprintf("%s = {%s}", clusterLabelsFieldName, strings.Join(items, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// selectSingleCluster returns the single matching cluster, or an error if none or multiple clusters match the lookup.
+func selectSingleCluster(matches []*qcCluster.Cluster, lookup string) (*qcCluster.Cluster, error) {
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no cluster found matching %s", lookup)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, cluster := range matches {
+			ids = append(ids, cluster.GetId())
+		}
+		sort.Strings(ids)
+		return nil, fmt.Errorf("%d clusters found matching %s (%s), please refine the lookup", len(matches), lookup, strings.Join(ids, ", "))
+	}
+}
+
+// importClusterState imports a cluster by ID, or by name if the import ID has the "name:" prefix (e.g. "name:prod-search").
+// The cluster is looked up in the default account of the provider.
+func importClusterState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	name, byName := strings.CutPrefix(d.Id(), clusterImportNamePrefix)
+	if !byName {
+		return []*schema.ResourceData{d}, nil
+	}
+	if name == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected %s<cluster name>", d.Id(), clusterImportNamePrefix)
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return nil, err
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, qcCluster.NewClusterServiceClient)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+	clusters, err := listClusters(clientCtx, client, accountUUID.String())
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := selectSingleCluster(matchClusters(clusters, name, nil), describeClusterLookup(name, nil))
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(cluster.GetId())
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,88 @@
+package qdrant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+func newLookupTestClusters() []*qcCluster.Cluster {
+	return []*qcCluster.Cluster{
+		{Id: "c1", Name: "prod-search", Labels: []*commonv1.KeyValue{{Key: "env", Value: "prod"}, {Key: "team", Value: "search"}}},
+		{Id: "c2", Name: "prod-recommend", Labels: []*commonv1.KeyValue{{Key: "env", Value: "prod"}, {Key: "team", Value: "recommend"}}},
+		{Id: "c3", Name: "dev-search", Labels: []*commonv1.KeyValue{{Key: "env", Value: "dev"}, {Key: "team", Value: "search"}}},
+	}
+}
+
+func clusterIDs(clusters []*qcCluster.Cluster) []string {
+	var ids []string
+	for _, c := range clusters {
+		ids = append(ids, c.GetId())
+	}
+	return ids
+}
+
+func TestMatchClusters(t *testing.T) {
+	clusters := newLookupTestClusters()
+
+	assert.Equal(t, []string{"c1"}, clusterIDs(matchClusters(clusters, "prod-search", nil)))
+	assert.Equal(t, []string{"c1", "c2"}, clusterIDs(matchClusters(clusters, "", []*commonv1.KeyValue{{Key: "env", Value: "prod"}})))
+	assert.Equal(t, []string{"c3"}, clusterIDs(matchClusters(clusters, "", []*commonv1.KeyValue{{Key: "env", Value: "dev"}, {Key: "team", Value: "search"}})))
+	assert.Equal(t, []string{"c1"}, clusterIDs(matchClusters(clusters, "prod-search", []*commonv1.KeyValue{{Key: "team", Value: "search"}})))
+	assert.Empty(t, matchClusters(clusters, "prod-search", []*commonv1.KeyValue{{Key: "env", Value: "dev"}}))
+	assert.Empty(t, matchClusters(clusters, "", []*commonv1.KeyValue{{Key: "owner", Value: "me"}}))
+}
+
+func TestDescribeClusterLookup(t *testing.T) {
+	assert.Equal(t, `name = "prod-search"`, describeClusterLookup("prod-search", nil))
+	assert.Equal(t, `name = "prod-search", labels = {env = "prod", team = "search"}`,
+		describeClusterLookup("prod-search", []*commonv1.KeyValue{{Key: "team", Value: "search"}, {Key: "env", Value: "prod"}}))
+}
+
+func TestSelectSingleCluster(t *testing.T) {
+	clusters := newLookupTestClusters()
+
+	cluster, err := selectSingleCluster(clusters[:1], "lookup")
+	require.NoError(t, err)
+	assert.Equal(t, "c1", cluster.GetId())
+
+	_, err = selectSingleCluster(nil, `name = "missing"`)
+	assert.EqualError(t, err, `no cluster found matching name = "missing"`)
+
+	_, err = selectSingleCluster([]*qcCluster.Cluster{clusters[1], clusters[0]}, `labels = {env = "prod"}`)
+	assert.EqualError(t, err, `2 clusters found matching labels = {env = "prod"} (c1, c2), please refine the lookup`)
+}
+
+func TestImportClusterState_ByID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, accountsClusterSchema(false), map[string]interface{}{})
+	d.SetId("12345678-0000-0000-0000-1234567890ab")
+
+	result, err := importClusterState(context.Background(), d, &ProviderConfig{})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "12345678-0000-0000-0000-1234567890ab", result[0].Id())
+}
+
+func TestImportClusterState_EmptyName(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, accountsClusterSchema(false), map[string]interface{}{})
+	d.SetId(clusterImportNamePrefix)
+
+	_, err := importClusterState(context.Background(), d, &ProviderConfig{})
+	assert.ErrorContains(t, err, `invalid import ID "name:", expected name:<cluster name>`)
+}
+
+func TestAccountsClusterLookupSchema(t *testing.T) {
+	s := accountsClusterLookupSchema()
+	for _, k := range []string{clusterIdentifierFieldName, clusterNameFieldName, clusterLabelsFieldName} {
+		assert.True(t, s[k].Optional, k)
+		assert.True(t, s[k].Computed, k)
+		assert.ElementsMatch(t, []string{clusterIdentifierFieldName, clusterNameFieldName, clusterLabelsFieldName}, s[k].AtLeastOneOf, k)
+	}
+	assert.ElementsMatch(t, []string{clusterNameFieldName, clusterLabelsFieldName}, s[clusterIdentifierFieldName].ConflictsWith)
+}
@@ -0,0 +1,131 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	clusterTlsMaterialFieldName               = "tls_material"
+	clusterTlsMaterialCertPemFieldName        = "cert_pem"
+	clusterTlsMaterialKeyPemFieldName         = "key_pem"
+	clusterTlsCertificateNotAfterFieldName    = "tls_certificate_not_after"
+	clusterTlsCertificateFingerprintFieldName = "tls_certificate_fingerprint"
+)
+
+// accountsClusterTlsMaterialSchema defines the schema for the TLS material of a cluster.
+// The material is client-side only: it is validated during plan, but never sent to the API
+// (the cluster reads it from the secrets referenced in database_configuration.tls).
+// The private key is write-only, so it is never stored in the Terraform state (see validateClusterTlsKeyPair).
+func accountsClusterTlsMaterialSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		clusterTlsMaterialCertPemFieldName: {
+			Description:      "PEM encoded certificate (optionally followed by its chain), e.g. loaded with `file()`. A warning is reported if a certificate is expired, not valid yet or expires within 30 days.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validateCertificatePEM),
+		},
+		clusterTlsMaterialKeyPemFieldName: {
+			Description:      "PEM encoded private key of the certificate, e.g. loaded with `file()`. Write-only (requires Terraform 1.11 or later): it is checked to match the certificate during plan, but never stored in the state.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			WriteOnly:        true,
+			ValidateDiagFunc: validation.ToDiagFunc(validatePrivateKeyPEM),
+		},
+	}
+}
+
+// clusterTlsMaterialInfo returns the expiry (RFC3339) and SHA-256 fingerprint of the (first) certificate in the provided PEM
+// (empty values if there is no certificate).
+func clusterTlsMaterialInfo(certPEM string) (string, string, error) {
+	if certPEM == "" {
+		return "", "", nil
+	}
+	certs, err := parsePEMCertificates(certPEM)
+	if err != nil {
+		return "", "", fmt.Errorf("%s %w", clusterTlsMaterialCertPemFieldName, err)
+	}
+	return formatCertificateTime(certs[0].NotAfter), certificateFingerprint(certs[0]), nil
+}
+
+// clusterTlsCertPemPath returns the path of the certificate in the TLS material of the cluster.
+func clusterTlsCertPemPath() string {
+	return fmt.Sprintf("%s.0.%s", clusterTlsMaterialFieldName, clusterTlsMaterialCertPemFieldName)
+}
+
+// planClusterTlsMaterial plans the expiry and fingerprint of the certificate in the TLS material of the cluster.
+// They are planned even if the material didn't change, so they are filled in for states missing them (e.g. after an import).
+func planClusterTlsMaterial(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(clusterTlsCertPemPath()) {
+		for _, k := range []string{clusterTlsCertificateNotAfterFieldName, clusterTlsCertificateFingerprintFieldName} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	certPEM, _ := d.Get(clusterTlsCertPemPath()).(string)
+	notAfter, fingerprint, err := clusterTlsMaterialInfo(certPEM)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", clusterTlsMaterialFieldName, err)
+	}
+	if err := d.SetNew(clusterTlsCertificateNotAfterFieldName, notAfter); err != nil {
+		return err
+	}
+	return d.SetNew(clusterTlsCertificateFingerprintFieldName, fingerprint)
+}
+
+// setClusterTlsMaterialInfo sets the expiry and fingerprint of the certificate in the TLS material of the cluster (as in the state),
+// so they stay consistent with it on refresh.
+func setClusterTlsMaterialInfo(d *schema.ResourceData) error {
+	certPEM, _ := d.Get(clusterTlsCertPemPath()).(string)
+	notAfter, fingerprint, err := clusterTlsMaterialInfo(certPEM)
+	if err != nil {
+		// Invalid material is reported during plan.
+		notAfter, fingerprint = "", ""
+	}
+	if err := d.Set(clusterTlsCertificateNotAfterFieldName, notAfter); err != nil {
+		return err
+	}
+	return d.Set(clusterTlsCertificateFingerprintFieldName, fingerprint)
+}
+
+// validateClusterTlsKeyPair ensures the (write-only) private key in the TLS material matches the certificate.
+// Write-only values are only part of the raw configuration, so the check can't be done in the CustomizeDiff.
+// Unknown values are not validated.
+func validateClusterTlsKeyPair(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+	material := req.RawConfig.GetAttr(clusterTlsMaterialFieldName)
+	if !material.IsKnown() || material.IsNull() || material.LengthInt() == 0 {
+		return
+	}
+	block := material.Index(cty.NumberIntVal(0))
+	if !block.IsKnown() || block.IsNull() {
+		return
+	}
+	certValue := block.GetAttr(clusterTlsMaterialCertPemFieldName)
+	keyValue := block.GetAttr(clusterTlsMaterialKeyPemFieldName)
+	if !certValue.IsKnown() || certValue.IsNull() || !keyValue.IsKnown() || keyValue.IsNull() {
+		return
+	}
+	if parsePEMPrivateKey(keyValue.AsString()) != nil {
+		// Reported by the attribute validation.
+		return
+	}
+	if err := validateCertificateKeyPair(certValue.AsString(), keyValue.AsString()); err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid TLS material",
+			Detail:        fmt.Sprintf("The %s in %s is invalid: %s.", clusterTlsMaterialKeyPemFieldName, clusterTlsMaterialFieldName, err),
+			AttributePath: cty.GetAttrPath(clusterTlsMaterialFieldName).IndexInt(0).GetAttr(clusterTlsMaterialKeyPemFieldName),
+		})
+	}
+}
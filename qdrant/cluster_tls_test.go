@@ -0,0 +1,124 @@
+package qdrant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterTlsMaterialInfo(t *testing.T) {
+	notAfter := time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC)
+	cert, _ := newTestCertificate(t, "cluster.example.com", false, notAfter.AddDate(-1, 0, 0), notAfter)
+	certs, err := parsePEMCertificates(cert)
+	require.NoError(t, err)
+
+	gotNotAfter, gotFingerprint, err := clusterTlsMaterialInfo(cert)
+	require.NoError(t, err)
+	assert.Equal(t, "2027-03-01T12:00:00Z", gotNotAfter)
+	assert.Equal(t, certificateFingerprint(certs[0]), gotFingerprint)
+
+	gotNotAfter, gotFingerprint, err = clusterTlsMaterialInfo("")
+	require.NoError(t, err)
+	assert.Empty(t, gotNotAfter)
+	assert.Empty(t, gotFingerprint)
+
+	_, _, err = clusterTlsMaterialInfo("cert")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), clusterTlsMaterialCertPemFieldName)
+}
+
+func TestSetClusterTlsMaterialInfo(t *testing.T) {
+	notAfter := time.Date(2027, 3, 1, 12, 0, 0, 0, time.UTC)
+	cert, _ := newTestCertificate(t, "cluster.example.com", false, notAfter.AddDate(-1, 0, 0), notAfter)
+
+	d := schema.TestResourceDataRaw(t, accountsClusterSchema(false), map[string]interface{}{
+		clusterTlsMaterialFieldName: []interface{}{map[string]interface{}{clusterTlsMaterialCertPemFieldName: cert}},
+	})
+	require.NoError(t, setClusterTlsMaterialInfo(d))
+	assert.Equal(t, "2027-03-01T12:00:00Z", d.Get(clusterTlsCertificateNotAfterFieldName))
+	assert.NotEmpty(t, d.Get(clusterTlsCertificateFingerprintFieldName))
+
+	// Without material (e.g. after an import) the attributes are empty
+	d = schema.TestResourceDataRaw(t, accountsClusterSchema(false), map[string]interface{}{})
+	require.NoError(t, setClusterTlsMaterialInfo(d))
+	assert.Empty(t, d.Get(clusterTlsCertificateNotAfterFieldName))
+	assert.Empty(t, d.Get(clusterTlsCertificateFingerprintFieldName))
+}
+
+func TestClusterTlsMaterialSchema(t *testing.T) {
+	s := accountsClusterSchema(false)
+	require.Contains(t, s, clusterTlsMaterialFieldName)
+	material := s[clusterTlsMaterialFieldName]
+	assert.True(t, material.Optional)
+	assert.False(t, material.Computed)
+	// The private key is write-only, so it isn't stored in the state
+	materialSchema := material.Elem.(*schema.Resource).Schema
+	assert.Len(t, materialSchema, 2)
+	assert.Contains(t, materialSchema, clusterTlsMaterialCertPemFieldName)
+	require.Contains(t, materialSchema, clusterTlsMaterialKeyPemFieldName)
+	assert.True(t, materialSchema[clusterTlsMaterialKeyPemFieldName].WriteOnly)
+	assert.True(t, materialSchema[clusterTlsMaterialKeyPemFieldName].Sensitive)
+	for _, k := range []string{clusterTlsCertificateNotAfterFieldName, clusterTlsCertificateFingerprintFieldName} {
+		require.Contains(t, s, k)
+		assert.True(t, s[k].Computed)
+		assert.False(t, s[k].Optional)
+	}
+}
+
+func TestValidateClusterTlsKeyPair(t *testing.T) {
+	now := time.Now()
+	cert, key := newTestCertificate(t, "leaf", false, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+	_, otherKey := newTestCertificate(t, "other", false, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+
+	validate := func(certPEM, keyPEM cty.Value) diag.Diagnostics {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		validateClusterTlsKeyPair(context.Background(), schema.ValidateResourceConfigFuncRequest{
+			WriteOnlyAttributesAllowed: true,
+			RawConfig: cty.ObjectVal(map[string]cty.Value{
+				clusterTlsMaterialFieldName: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					clusterTlsMaterialCertPemFieldName: certPEM,
+					clusterTlsMaterialKeyPemFieldName:  keyPEM,
+				})}),
+			}),
+		}, resp)
+		return resp.Diagnostics
+	}
+
+	t.Run("matching pair", func(t *testing.T) {
+		assert.Empty(t, validate(cty.StringVal(cert), cty.StringVal(key)))
+	})
+	t.Run("mismatched pair", func(t *testing.T) {
+		diags := validate(cty.StringVal(cert), cty.StringVal(otherKey))
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Error, diags[0].Severity)
+		assert.Contains(t, diags[0].Detail, "doesn't match the certificate")
+		assert.Equal(t, cty.GetAttrPath(clusterTlsMaterialFieldName).IndexInt(0).GetAttr(clusterTlsMaterialKeyPemFieldName), diags[0].AttributePath)
+	})
+	t.Run("no or unknown key", func(t *testing.T) {
+		assert.Empty(t, validate(cty.StringVal(cert), cty.NullVal(cty.String)))
+		assert.Empty(t, validate(cty.StringVal(cert), cty.UnknownVal(cty.String)))
+		assert.Empty(t, validate(cty.UnknownVal(cty.String), cty.StringVal(otherKey)))
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		// Reported by the attribute validation
+		assert.Empty(t, validate(cty.StringVal(cert), cty.StringVal(cert)))
+	})
+	t.Run("no material", func(t *testing.T) {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		validateClusterTlsKeyPair(context.Background(), schema.ValidateResourceConfigFuncRequest{
+			RawConfig: cty.ObjectVal(map[string]cty.Value{
+				clusterTlsMaterialFieldName: cty.ListValEmpty(cty.Object(map[string]cty.Type{
+					clusterTlsMaterialCertPemFieldName: cty.String,
+					clusterTlsMaterialKeyPemFieldName:  cty.String,
+				})),
+			}),
+		}, resp)
+		assert.Empty(t, resp.Diagnostics)
+	})
+}
@@ -0,0 +1,236 @@
+package qdrant
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed standard 5-field cron expression (minute, hour, day of month, month, day of week).
+// Each field is stored as a bitset of the allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domRestricted and dowRestricted reflect if the day fields were given explicitly (not "*"),
+	// if both are restricted a time matches when either of them matches (like Vixie cron).
+	domRestricted, dowRestricted bool
+}
+
+// cronField describes the bounds and (optional) names of a single cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinuteField = cronField{name: "minute", min: 0, max: 59}
+	cronHourField   = cronField{name: "hour", min: 0, max: 23}
+	cronDomField    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week accepts 0-7, where both 0 and 7 are Sunday.
+	cronDowField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	// cronMacros contains the supported predefined schedules.
+	cronMacros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// parseCronExpression parses a standard 5-field cron expression or one of the supported macros
+// (@yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly).
+func parseCronExpression(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		spec, ok := cronMacros[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported macro %q", expr)
+		}
+		expr = spec
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+	s := &cronSchedule{}
+	var err error
+	if s.minute, err = parseCronField(fields[0], cronMinuteField); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseCronField(fields[1], cronHourField); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseCronField(fields[2], cronDomField); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseCronField(fields[3], cronMonthField); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseCronField(fields[4], cronDowField); err != nil {
+		return nil, err
+	}
+	// Sunday can be written as 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow = (s.dow | 1) &^ (1 << 7)
+	}
+	s.domRestricted = !strings.HasPrefix(fields[2], "*")
+	s.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseCronField parses a single cron field (e.g. "*/15", "1-5", "mon,wed,fri") into a bitset.
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, field.name)
+			}
+			step = n
+		}
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = field.min, field.max
+		case strings.Contains(rangePart, "-"):
+			lo, hi, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(lo, field); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(hi, field); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangePart, field.name)
+			}
+		default:
+			var err error
+			if start, err = parseCronValue(rangePart, field); err != nil {
+				return 0, err
+			}
+			end = start
+			// A single value with a step (e.g. "5/10") means "starting at 5".
+			if hasStep {
+				end = field.max
+			}
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parses a single numeric (or named) value of a cron field and checks its bounds.
+func parseCronValue(value string, field cronField) (int, error) {
+	if n, ok := field.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, field.name)
+	}
+	if n < field.min || n > field.max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d] in %s field", n, field.min, field.max, field.name)
+	}
+	return n, nil
+}
+
+// next returns the first time strictly after t (truncated to the minute) matching the schedule,
+// or the zero time if no such time exists within the next five years (e.g. "0 0 30 2 *").
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches returns true if the day of t matches the day of month and day of week fields.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// nextRuns returns the next n times after from (in UTC) matching the schedule.
+func (s *cronSchedule) nextRuns(from time.Time, n int) []time.Time {
+	var result []time.Time
+	t := from.UTC()
+	for len(result) < n {
+		t = s.next(t)
+		if t.IsZero() {
+			break
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// maxInterval returns the largest gap between two consecutive runs of the schedule
+// within the year following from (or zero if the schedule runs less than twice in that period).
+func (s *cronSchedule) maxInterval(from time.Time) time.Duration {
+	var result time.Duration
+	prev := s.next(from.UTC())
+	if prev.IsZero() {
+		return 0
+	}
+	limit := prev.AddDate(1, 0, 0)
+	for {
+		t := s.next(prev)
+		if t.IsZero() || t.After(limit) {
+			return result
+		}
+		if gap := t.Sub(prev); gap > result {
+			result = gap
+		}
+		prev = t
+	}
+}
+
+// validateCronExpression is a SchemaValidateFunc that ensures the provided value is a valid cron expression.
+func validateCronExpression(v interface{}, k string) (ws []string, es []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a string", k)}
+	}
+	if _, err := parseCronExpression(s); err != nil {
+		return nil, []error{fmt.Errorf("%s: %q is not a valid cron expression: %w", k, s, err)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,133 @@
+package qdrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronExpression_Valid(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"0 0 1 * *",
+		"*/15 2-5 * * mon-fri",
+		"0,30 12 1,15 jan,jul *",
+		"5/10 * * * *",
+		"0 0 * * 7",
+		"@daily",
+		"@Weekly",
+		"  0 3 * * *  ",
+	} {
+		_, err := parseCronExpression(expr)
+		assert.NoError(t, err, expr)
+	}
+}
+
+func TestParseCronExpression_Invalid(t *testing.T) {
+	for expr, msg := range map[string]string{
+		"":              "expected 5 fields",
+		"0 0 * *":       "expected 5 fields",
+		"0 0 * * * *":   "expected 5 fields",
+		"60 * * * *":    "out of range",
+		"* 24 * * *":    "out of range",
+		"* * 0 * *":     "out of range",
+		"* * * 13 *":    "out of range",
+		"* * * * 8":     "out of range",
+		"*/0 * * * *":   "invalid step",
+		"5-1 * * * *":   "invalid range",
+		"a * * * *":     "invalid value",
+		"* * * foo *":   "invalid value",
+		"@every 1h":     "unsupported macro",
+		"@fortnightly":  "unsupported macro",
+		"* * * * mon-x": "invalid value",
+	} {
+		_, err := parseCronExpression(expr)
+		require.Error(t, err, expr)
+		assert.Contains(t, err.Error(), msg, expr)
+	}
+}
+
+func TestCronSchedule_NextRuns(t *testing.T) {
+	from := time.Date(2024, 1, 30, 10, 17, 42, 0, time.UTC)
+	tests := map[string][]time.Time{
+		"*/20 * * * *": {
+			time.Date(2024, 1, 30, 10, 20, 0, 0, time.UTC),
+			time.Date(2024, 1, 30, 10, 40, 0, 0, time.UTC),
+			time.Date(2024, 1, 30, 11, 0, 0, 0, time.UTC),
+		},
+		"@monthly": {
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"0 0 31 * *": {
+			time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+		},
+		"0 0 29 2 *": {
+			time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		// Sunday written as 7.
+		"30 6 * * 7": {
+			time.Date(2024, 2, 4, 6, 30, 0, 0, time.UTC),
+			time.Date(2024, 2, 11, 6, 30, 0, 0, time.UTC),
+			time.Date(2024, 2, 18, 6, 30, 0, 0, time.UTC),
+		},
+		// Day of month and day of week both restricted: either matches.
+		"0 0 1 * fri": {
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for expr, expected := range tests {
+		cron, err := parseCronExpression(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, expected, cron.nextRuns(from, len(expected)), expr)
+	}
+}
+
+func TestCronSchedule_NextRunsNeverMatches(t *testing.T) {
+	cron, err := parseCronExpression("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.Empty(t, cron.nextRuns(time.Now(), 3))
+	assert.Zero(t, cron.maxInterval(time.Now()))
+}
+
+func TestCronSchedule_MaxInterval(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"@hourly":       time.Hour,
+		"@daily":        24 * time.Hour,
+		"0 0,6 * * *":   18 * time.Hour,
+		"0 0 * * 1-5":   3 * 24 * time.Hour,
+		"@weekly":       7 * 24 * time.Hour,
+		"@monthly":      31 * 24 * time.Hour,
+		"0 0 31 * *":    61 * 24 * time.Hour,
+		"*/30 * * * *":  30 * time.Minute,
+		"0 12 1 1,7 *":  184 * 24 * time.Hour,
+		"15 3 * * sun":  7 * 24 * time.Hour,
+		"0 0 1-7 * mon": 7 * 24 * time.Hour,
+	}
+	for expr, expected := range tests {
+		cron, err := parseCronExpression(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, expected, cron.maxInterval(from), expr)
+	}
+}
+
+func TestValidateCronExpression(t *testing.T) {
+	_, errs := validateCronExpression("0 0 * * *", "cron_expression")
+	assert.Empty(t, errs)
+
+	_, errs = validateCronExpression("0 0 * *", "cron_expression")
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "cron_expression")
+
+	_, errs = validateCronExpression(1, "cron_expression")
+	assert.Len(t, errs, 1)
+}
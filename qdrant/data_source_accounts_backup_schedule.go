@@ -3,6 +3,7 @@ package qdrant
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -45,6 +46,7 @@ func dataAccountsBackupScheduleRead(ctx context.Context, d *schema.ResourceData,
 	d.SetId(resp.GetBackupSchedule().GetId())
 
 	flattened := flattenBackupSchedule(resp.GetBackupSchedule())
+	flattened[backupScheduleNextRunsFieldName] = flattenBackupScheduleNextRuns(resp.GetBackupSchedule().GetSchedule(), time.Now())
 	for k, v := range flattened {
 		if err := d.Set(k, v); err != nil {
 			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
@@ -3,6 +3,7 @@ package qdrant
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -42,7 +43,12 @@ func dataAccountsBackupSchedulesRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 	}
 
-	if err := d.Set(backupSchedulesFieldName, flattenBackupSchedules(resp.GetItems())); err != nil {
+	schedules := flattenBackupSchedules(resp.GetItems())
+	now := time.Now()
+	for i, item := range resp.GetItems() {
+		schedules[i].(map[string]interface{})[backupScheduleNextRunsFieldName] = flattenBackupScheduleNextRuns(item.GetSchedule(), now)
+	}
+	if err := d.Set(backupSchedulesFieldName, schedules); err != nil {
 		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 	}
 
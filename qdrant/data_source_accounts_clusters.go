@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
 )
 
 // dataSourceAccountsClusters constructs a Terraform resource for
@@ -29,11 +30,12 @@ func dataSourceAccountsCluster() *schema.Resource {
 	return &schema.Resource{
 		Description: "Account Cluster Data Source",
 		ReadContext: dataSourceAccountsClusterRead,
-		Schema:      accountsClusterSchema(true),
+		Schema:      accountsClusterLookupSchema(),
 	}
 }
 
-// dataSourceAccountsClustersRead performs a read operation to fetch all clusters associated with a specific account.
+// dataSourceAccountsClustersRead performs a read operation to fetch all clusters associated with a specific account,
+// which meet the configured filters.
 // ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
 // d: Resource data which is used to manage the state of the resource.
 // m: The interface where the configured client is passed.
@@ -49,22 +51,42 @@ func dataSourceAccountsClustersRead(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 	}
-	// List all clusters for the provided account
-	var trailer metadata.MD
-	resp, err := client.ListClusters(clientCtx, &qcCluster.ListClustersRequest{
+	filter, err := expandClusterFilter(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// List the clusters for the provided account, the cloud provider and region are filtered by the API
+	req := &qcCluster.ListClustersRequest{
 		AccountId: accountUUID.String(),
-	}, grpc.Trailer(&trailer))
-	// enrich prefix with request ID
-	errorPrefix += getRequestID(trailer)
+	}
+	if filter.CloudProvider != "" {
+		req.CloudProviderId = newPointer(filter.CloudProvider)
+	}
+	if filter.CloudRegion != "" {
+		req.CloudProviderRegionId = newPointer(filter.CloudRegion)
+	}
+	items, err := listClustersWithRequest(clientCtx, client, req)
 	if err != nil {
-		d := diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
-		if d.HasError() {
-			return d
-		}
+		return diag.FromErr(err)
 	}
 	// Update the Terraform state
-	if err := d.Set("clusters", flattenClusters(resp.GetItems())); err != nil {
-		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	items = filterClusters(items, filter)
+	clusters := make([]interface{}, len(items))
+	ids := make([]string, len(items))
+	names := make([]string, len(items))
+	for i, cluster := range items {
+		clusters[i] = flattenCluster(cluster)
+		ids[i] = cluster.GetId()
+		names[i] = cluster.GetName()
+	}
+	for k, v := range map[string]interface{}{
+		clustersClustersFieldName: clusters,
+		clustersIDsFieldName:      ids,
+		clustersNamesFieldName:    names,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
 	}
 
 	d.SetId(time.Now().UTC().Format(time.RFC3339))
@@ -87,8 +109,24 @@ func dataSourceAccountsClusterRead(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 	}
-	// Get the cluster ID
+	// Get the cluster ID, or look it up by name and/or labels
 	clusterID := d.Get("id").(string)
+	if clusterID == "" {
+		name := d.Get(clusterNameFieldName).(string)
+		var labels []*commonv1.KeyValue
+		if v, ok := d.GetOk(clusterLabelsFieldName); ok {
+			labels = expandKeyVal(v.(*schema.Set).List())
+		}
+		clusters, err := listClusters(clientCtx, client, accountUUID.String())
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+		cluster, err := selectSingleCluster(matchClusters(clusters, name, labels), describeClusterLookup(name, labels))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+		clusterID = cluster.GetId()
+	}
 	// Fetch the cluster
 	var trailer metadata.MD
 	resp, err := client.GetCluster(clientCtx, &qcCluster.GetClusterRequest{
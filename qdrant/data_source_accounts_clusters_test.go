@@ -20,10 +20,19 @@ provider "qdrant-cloud" {
 data "qdrant-cloud_accounts_clusters" "test" {
 	account_id = "%s"
 }
-`, os.Getenv("QDRANT_CLOUD_ACCOUNT_ID"))
+
+data "qdrant-cloud_accounts_clusters" "filtered" {
+	account_id     = "%s"
+	label_selector = "!tf-acc-test-nonexistent-label"
+	name_regex     = ".*"
+}
+`, os.Getenv("QDRANT_CLOUD_ACCOUNT_ID"), os.Getenv("QDRANT_CLOUD_ACCOUNT_ID"))
 
 	check := resource.ComposeTestCheckFunc(
 		resource.TestCheckResourceAttrSet("data.qdrant-cloud_accounts_clusters.test", "clusters.#"),
+		resource.TestCheckResourceAttrSet("data.qdrant-cloud_accounts_clusters.test", "ids.#"),
+		resource.TestCheckResourceAttrPair("data.qdrant-cloud_accounts_clusters.test", "ids.#", "data.qdrant-cloud_accounts_clusters.filtered", "ids.#"),
+		resource.TestCheckResourceAttrPair("data.qdrant-cloud_accounts_clusters.test", "names.#", "data.qdrant-cloud_accounts_clusters.filtered", "names.#"),
 	)
 
 	resource.Test(t, resource.TestCase{
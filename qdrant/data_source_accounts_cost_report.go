@@ -0,0 +1,80 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+// dataSourceAccountsCostReport constructs a Terraform data source for
+// estimating the cost of all clusters of a Qdrant Cloud account.
+func dataSourceAccountsCostReport() *schema.Resource {
+	return &schema.Resource{
+		Description: "Account Cost Report Data Source. Estimates the cost of all clusters in a Qdrant Cloud account, based on the booking catalog.",
+		ReadContext: dataSourceAccountsCostReportRead,
+		Schema:      accountsCostReportSchema(),
+	}
+}
+
+// dataSourceAccountsCostReportRead performs a read operation to estimate the cost of all clusters associated with a specific account.
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+// Returns diagnostic information encapsulating any runtime issues encountered during the API call.
+func dataSourceAccountsCostReportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error creating cost report"
+	client, clientCtx, diags := getServiceClient(ctx, m, qcCluster.NewClusterServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	// Get the account ID as UUID.
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// List all clusters for the provided account.
+	var trailer metadata.MD
+	resp, err := client.ListClusters(clientCtx, &qcCluster.ListClustersRequest{
+		AccountId: accountUUID.String(),
+	}, grpc.Trailer(&trailer))
+	// Enrich prefix with request ID.
+	errorPrefix += getRequestID(trailer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Estimate the cost of every cluster, clusters which cannot be priced are reported as such.
+	var diagnostics diag.Diagnostics
+	clusters, totals, unpriced := flattenCostReport(resp.GetItems(), func(cluster *qcCluster.Cluster) (clusterCostEstimate, bool) {
+		estimate, ok, err := lookupClusterCostEstimate(ctx, m, accountUUID.String(), cluster)
+		if err != nil {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("cannot estimate the cost of cluster %s (%s)", cluster.GetName(), cluster.GetId()),
+				Detail:   err.Error(),
+			})
+		}
+		return estimate, ok
+	})
+	// Store the report in Terraform state.
+	if err := d.Set(costReportClustersFieldName, clusters); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	if err := d.Set(costReportTotalsFieldName, totals); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	if err := d.Set(costReportUnpricedCountFieldName, unpriced); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	if err := d.Set(costReportAccountIDFieldName, accountUUID.String()); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	d.SetId(time.Now().UTC().Format(time.RFC3339))
+	return diagnostics
+}
@@ -0,0 +1,124 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+// dataSourceAccountsHybridCloudEnvironments constructs a Terraform resource for
+// managing the reading of all hybrid cloud environments associated with an account.
+func dataSourceAccountsHybridCloudEnvironments() *schema.Resource {
+	return &schema.Resource{
+		Description: "Account Hybrid Cloud Environment List Data Source",
+		ReadContext: dataSourceAccountsHybridCloudEnvironmentsRead,
+		Schema:      accountsHybridCloudEnvironmentsSchema(),
+	}
+}
+
+// dataSourceAccountsHybridCloudEnvironment constructs a Terraform resource for
+// managing the reading of a specific hybrid cloud environment associated with an account.
+func dataSourceAccountsHybridCloudEnvironment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Account Hybrid Cloud Environment Data Source",
+		ReadContext: dataSourceAccountsHybridCloudEnvironmentRead,
+		Schema:      accountsHybridCloudEnvironmentLookupSchema(),
+	}
+}
+
+// dataSourceAccountsHybridCloudEnvironmentsRead performs a read operation to fetch all hybrid cloud environments
+// associated with a specific account (optionally filtered by status phase).
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+// Returns diagnostic information encapsulating any runtime issues encountered during the API call.
+func dataSourceAccountsHybridCloudEnvironmentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error listing hybrid cloud environments"
+	client, clientCtx, diags := getServiceClient(ctx, m, qch.NewHybridCloudServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	// Get The account ID as UUID
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	items, err := listHCEnvs(clientCtx, client, accountUUID.String())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Update the Terraform state
+	items = matchHCEnvs(items, "", d.Get(hcEnvsPhaseFieldName).(string))
+	envs := make([]interface{}, len(items))
+	for i, env := range items {
+		envs[i] = flattenHCEnv(env)
+	}
+	if err := d.Set(hcEnvsHybridCloudEnvironmentsFieldName, envs); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	if err := d.Set(hcEnvsAccountIdFieldName, accountUUID.String()); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+
+	d.SetId(time.Now().UTC().Format(time.RFC3339))
+	return nil
+}
+
+// dataSourceAccountsHybridCloudEnvironmentRead performs a read operation to fetch a specific hybrid cloud environment
+// by ID, or by name (which should match a single environment).
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+// Returns diagnostic information encapsulating any runtime issues encountered during the API call.
+func dataSourceAccountsHybridCloudEnvironmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error getting hybrid cloud environment"
+	client, clientCtx, diags := getServiceClient(ctx, m, qch.NewHybridCloudServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	// Get The account ID as UUID
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Get the environment ID, or look it up by name
+	envID := d.Get(hcEnvIdFieldName).(string)
+	if envID == "" {
+		name := d.Get(hcEnvNameFieldName).(string)
+		envs, err := listHCEnvs(clientCtx, client, accountUUID.String())
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+		env, err := selectSingleHCEnv(matchHCEnvs(envs, name, ""), name)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+		envID = env.GetId()
+	}
+	// Fetch the hybrid cloud environment
+	var trailer metadata.MD
+	resp, err := client.GetHybridCloudEnvironment(clientCtx, &qch.GetHybridCloudEnvironmentRequest{
+		AccountId:                accountUUID.String(),
+		HybridCloudEnvironmentId: envID,
+	}, grpc.Trailer(&trailer))
+	// enrich prefix with request ID
+	errorPrefix += getRequestID(trailer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Flatten hybrid cloud environment (including the status) and store in Terraform state
+	for k, v := range flattenHCEnv(resp.GetHybridCloudEnvironment()) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+	}
+	d.SetId(envID)
+	return nil
+}
@@ -0,0 +1,64 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+// dataSourceAccountsHybridCloudEnvironmentHealth constructs a Terraform resource for
+// reading the health (status of the environment and its components) of a hybrid cloud environment.
+func dataSourceAccountsHybridCloudEnvironmentHealth() *schema.Resource {
+	return &schema.Resource{
+		Description: "Account Hybrid Cloud Environment Health Data Source",
+		ReadContext: dataSourceAccountsHybridCloudEnvironmentHealthRead,
+		Schema:      accountsHybridCloudEnvironmentHealthSchema(),
+	}
+}
+
+// dataSourceAccountsHybridCloudEnvironmentHealthRead performs a read operation to fetch the health of a hybrid cloud environment.
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+// Returns diagnostic information encapsulating any runtime issues encountered during the API call.
+func dataSourceAccountsHybridCloudEnvironmentHealthRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error getting hybrid cloud environment health"
+	client, clientCtx, diags := getServiceClient(ctx, m, qch.NewHybridCloudServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	// Get The account ID as UUID
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	envID := d.Get(hcEnvHealthEnvironmentIdFieldName).(string)
+	// Fetch the hybrid cloud environment
+	var trailer metadata.MD
+	resp, err := client.GetHybridCloudEnvironment(clientCtx, &qch.GetHybridCloudEnvironmentRequest{
+		AccountId:                accountUUID.String(),
+		HybridCloudEnvironmentId: envID,
+	}, grpc.Trailer(&trailer))
+	// enrich prefix with request ID
+	errorPrefix += getRequestID(trailer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Flatten the health and store in Terraform state
+	for k, v := range flattenHCEnvHealth(resp.GetHybridCloudEnvironment()) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+	}
+	if err := d.Set(hcEnvHealthAccountIdFieldName, accountUUID.String()); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	d.SetId(envID)
+	return nil
+}
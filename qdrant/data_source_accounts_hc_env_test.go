@@ -0,0 +1,68 @@
+package qdrant
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataAccountsHybridCloudEnvironments(t *testing.T) {
+	provider := fmt.Sprintf(`
+provider "qdrant-cloud" {
+  api_key = "%s"
+}
+`, os.Getenv("QDRANT_CLOUD_API_KEY"))
+
+	config := provider + fmt.Sprintf(`
+data "qdrant-cloud_accounts_hybrid_cloud_environments" "test" {
+	account_id = "%s"
+}
+`, os.Getenv("QDRANT_CLOUD_ACCOUNT_ID"))
+
+	check := resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttrSet("data.qdrant-cloud_accounts_hybrid_cloud_environments.test", "hybrid_cloud_environments.#"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			//nolint:unparam // Ignoring unparam as we know error will always be nil.
+			"qdrant-cloud": func() (*schema.Provider, error) {
+				return Provider(), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:  config,
+				Destroy: true,
+				Check:   check,
+			},
+		},
+	})
+}
+
+func TestAccountsHybridCloudEnvironmentDataSourceSchema(t *testing.T) {
+	s := accountsHybridCloudEnvironmentSchema(true)
+
+	// Resource only fields aren't part of the data source
+	for _, k := range []string{hcEnvBootstrapCommandsFieldName, hcEnvBootstrapFieldName, hcEnvBootstrapCommandsVersionFieldName, hcEnvWaitForReadyFieldName, hcEnvRegenerateOnChangeFieldName, hcEnvForceDestroyFieldName} {
+		assert.NotContains(t, s, k)
+	}
+	// The configuration is read-only
+	assert.True(t, s[hcEnvNameFieldName].Computed)
+	assert.False(t, s[hcEnvNameFieldName].Required)
+	cfg := s[hcEnvConfigurationFieldName].Elem.(*schema.Resource).Schema
+	for k, v := range cfg {
+		assert.False(t, v.Optional || v.Required, "%s should not be configurable", k)
+		assert.True(t, v.Computed, "%s should be computed", k)
+		assert.Nil(t, v.ValidateDiagFunc, "%s should not be validated", k)
+	}
+
+	lookup := accountsHybridCloudEnvironmentLookupSchema()
+	assert.True(t, lookup[hcEnvIdFieldName].Optional)
+	assert.True(t, lookup[hcEnvNameFieldName].Optional)
+	assert.Equal(t, []string{hcEnvIdFieldName, hcEnvNameFieldName}, lookup[hcEnvNameFieldName].ExactlyOneOf)
+}
@@ -0,0 +1,130 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+)
+
+// dataSourceBookingPackage returns the schema for the data source qdrant_booking_package.
+func dataSourceBookingPackage() *schema.Resource {
+	return &schema.Resource{
+		Description: "Booking package Data Source (selects the cheapest package meeting the requirements)",
+		ReadContext: dataBookingPackageRead,
+		Schema:      packageSelectorSchema(),
+	}
+}
+
+// dataBookingPackageRead selects the cheapest package meeting the requirements and sets it into the Terraform state.
+// d: The Terraform ResourceData object containing the state.
+// m: The Terraform meta object containing the client configuration.
+func dataBookingPackageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error selecting package"
+	client, clientCtx, diags := getServiceClient(ctx, m, qcBooking.NewBookingServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	// Get The account ID as UUID
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	req, err := expandBookingPackageRequirements(d)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Get all active packages
+	cloudProvider := d.Get(clusterCloudProviderFieldName).(string)
+	cloudRegion := d.Get(clusterCloudRegionFieldName).(string)
+	var trailer metadata.MD
+	resp, err := client.ListPackages(clientCtx, &qcBooking.ListPackagesRequest{
+		AccountId:             accountUUID.String(),
+		CloudProviderId:       cloudProvider,
+		CloudProviderRegionId: newPointer(cloudRegion),
+		Statuses:              []qcBooking.PackageStatus{qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE},
+	}, grpc.Trailer(&trailer))
+	// enrich prefix with request ID
+	errorPrefix += getRequestID(trailer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Select the cheapest package
+	pkg, err := selectCheapestPackage(resp.GetItems(), req)
+	if err != nil {
+		return diag.Errorf("%s in %s/%s: %v (%s)", errorPrefix, cloudProvider, cloudRegion, err, describeBookingPackageRequirements(d, req))
+	}
+	// Set the package in the Terraform state.
+	for k, v := range flattenPackages([]*qcBooking.Package{pkg})[0].(map[string]interface{}) {
+		if k == fieldID {
+			continue
+		}
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+	}
+	d.SetId(pkg.GetId())
+	return nil
+}
+
+// expandBookingPackageRequirements returns the package requirements of the provided configuration.
+func expandBookingPackageRequirements(d *schema.ResourceData) (bookingPackageRequirements, error) {
+	var req bookingPackageRequirements
+	var err error
+	if req.MinRam, err = parseResourceQuantity(d.Get(fieldMinRam).(string)); err != nil {
+		return req, err
+	}
+	if req.MinCpu, err = parseResourceQuantity(d.Get(fieldMinCpu).(string)); err != nil {
+		return req, err
+	}
+	if req.MinDisk, err = parseResourceQuantity(d.Get(fieldMinDisk).(string)); err != nil {
+		return req, err
+	}
+	// Booleans are only a requirement if they are explicitly configured.
+	rawConfig := d.GetRawConfig()
+	if !rawConfig.IsNull() {
+		if v := rawConfig.GetAttr(fieldGpu); v.IsKnown() && !v.IsNull() {
+			req.Gpu = newPointer(v.True())
+		}
+		if v := rawConfig.GetAttr(fieldMultiAz); v.IsKnown() && !v.IsNull() {
+			req.MultiAz = newPointer(v.True())
+		}
+		if v := rawConfig.GetAttr(fieldTier); v.IsKnown() && !v.IsNull() {
+			req.Tier = v.AsString()
+		}
+	}
+	req.StorageTierType = d.Get(fieldStorageTierType).(string)
+	return req, nil
+}
+
+// describeBookingPackageRequirements returns a human readable description of the configured requirements.
+func describeBookingPackageRequirements(d *schema.ResourceData, req bookingPackageRequirements) string {
+	var parts []string
+	for _, k := range []string{fieldMinRam, fieldMinCpu, fieldMinDisk} {
+		if v := d.Get(k).(string); v != "" {
+			parts = append(parts, fmt.Sprintf("%s = %s", k, v))
+		}
+	}
+	if req.Gpu != nil {
+		parts = append(parts, fmt.Sprintf("%s = %t", fieldGpu, *req.Gpu))
+	}
+	if req.Tier != "" {
+		parts = append(parts, fmt.Sprintf("%s = %s", fieldTier, req.Tier))
+	}
+	if req.MultiAz != nil {
+		parts = append(parts, fmt.Sprintf("%s = %t", fieldMultiAz, *req.MultiAz))
+	}
+	if req.StorageTierType != "" {
+		parts = append(parts, fmt.Sprintf("%s = %s", fieldStorageTierType, req.StorageTierType))
+	}
+	if len(parts) == 0 {
+		return "no requirements"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,61 @@
+package qdrant
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataBookingPackage(t *testing.T) {
+	apiKey := os.Getenv("QDRANT_CLOUD_API_KEY")
+	cloudProvider := getEnvDefault("QDRANT_CLOUD_CLOUD_PROVIDER", "aws")
+	cloudRegion := getEnvDefault("QDRANT_CLOUD_REGION", "eu-central-1")
+
+	provider := fmt.Sprintf(`
+provider "qdrant-cloud" {
+  api_key = "%s"
+}
+`, apiKey)
+
+	config := provider + fmt.Sprintf(`
+data "qdrant-cloud_booking_package" "test" {
+  cloud_provider = "%s"
+  cloud_region   = "%s"
+  min_ram        = "8GiB"
+  min_cpu        = "2"
+  gpu            = false
+}
+`, cloudProvider, cloudRegion)
+
+	noMatchConfig := provider + fmt.Sprintf(`
+data "qdrant-cloud_booking_package" "test" {
+  cloud_provider = "%s"
+  cloud_region   = "%s"
+  min_ram        = "100Ti"
+}
+`, cloudProvider, cloudRegion)
+
+	check := resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttrSet("data.qdrant-cloud_booking_package.test", "id"),
+		resource.TestCheckResourceAttrSet("data.qdrant-cloud_booking_package.test", "name"),
+		resource.TestCheckResourceAttrSet("data.qdrant-cloud_booking_package.test", "unit_int_price_per_hour"),
+		resource.TestCheckResourceAttr("data.qdrant-cloud_booking_package.test", "status", "PACKAGE_STATUS_ACTIVE"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  check,
+			},
+			{
+				Config:      noMatchConfig,
+				ExpectError: regexp.MustCompile("available packages meets the requirements"),
+			},
+		},
+	})
+}
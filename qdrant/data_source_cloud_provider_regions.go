@@ -0,0 +1,62 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qcPlatform "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/platform/v1"
+)
+
+// dataSourceCloudProviderRegions constructs a Terraform data source for
+// listing the regions of a cloud provider clusters can be created in.
+func dataSourceCloudProviderRegions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Cloud Provider Regions Data Source. Lists the regions of a cloud provider clusters can be created in.",
+		ReadContext: dataSourceCloudProviderRegionsRead,
+		Schema:      cloudProviderRegionsSchema(),
+	}
+}
+
+// dataSourceCloudProviderRegionsRead performs a read operation to fetch all regions of a cloud provider available for a specific account.
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+// Returns diagnostic information encapsulating any runtime issues encountered during the API call.
+func dataSourceCloudProviderRegionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error listing cloud provider regions"
+	client, clientCtx, diags := getServiceClient(ctx, m, qcPlatform.NewPlatformServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	// Get the account ID as UUID.
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// List all regions of the cloud provider for the provided account.
+	var trailer metadata.MD
+	resp, err := client.ListCloudProviderRegions(clientCtx, &qcPlatform.ListCloudProviderRegionsRequest{
+		AccountId:       accountUUID.String(),
+		CloudProviderId: d.Get(platformCloudProviderFieldName).(string),
+	}, grpc.Trailer(&trailer))
+	// Enrich prefix with request ID.
+	errorPrefix += getRequestID(trailer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Flatten regions and store in Terraform state.
+	if err := d.Set(platformRegionsFieldName, flattenCloudProviderRegions(resp.GetItems())); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	if err := d.Set(platformAccountIDFieldName, accountUUID.String()); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	d.SetId(time.Now().UTC().Format(time.RFC3339))
+	return nil
+}
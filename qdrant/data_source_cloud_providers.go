@@ -0,0 +1,61 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qcPlatform "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/platform/v1"
+)
+
+// dataSourceCloudProviders constructs a Terraform data source for
+// listing the cloud providers clusters can be created in.
+func dataSourceCloudProviders() *schema.Resource {
+	return &schema.Resource{
+		Description: "Cloud Providers Data Source. Lists the cloud providers clusters can be created in.",
+		ReadContext: dataSourceCloudProvidersRead,
+		Schema:      cloudProvidersSchema(),
+	}
+}
+
+// dataSourceCloudProvidersRead performs a read operation to fetch all cloud providers available for a specific account.
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+// Returns diagnostic information encapsulating any runtime issues encountered during the API call.
+func dataSourceCloudProvidersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error listing cloud providers"
+	client, clientCtx, diags := getServiceClient(ctx, m, qcPlatform.NewPlatformServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	// Get the account ID as UUID.
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// List all cloud providers for the provided account.
+	var trailer metadata.MD
+	resp, err := client.ListCloudProviders(clientCtx, &qcPlatform.ListCloudProvidersRequest{
+		AccountId: accountUUID.String(),
+	}, grpc.Trailer(&trailer))
+	// Enrich prefix with request ID.
+	errorPrefix += getRequestID(trailer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Flatten cloud providers and store in Terraform state.
+	if err := d.Set(platformCloudProvidersFieldName, flattenCloudProviders(resp.GetItems())); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	if err := d.Set(platformAccountIDFieldName, accountUUID.String()); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	d.SetId(time.Now().UTC().Format(time.RFC3339))
+	return nil
+}
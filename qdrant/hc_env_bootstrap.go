@@ -0,0 +1,510 @@
+package qdrant
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	hcEnvBootstrapFieldName                 = "bootstrap"
+	hcEnvBootstrapNamespaceFieldName        = "namespace"
+	hcEnvBootstrapHelmReleasesFieldName     = "helm_releases"
+	hcEnvBootstrapSecretsFieldName          = "secrets"
+	hcEnvBootstrapManifestsFieldName        = "manifests"
+	hcEnvBootstrapHelmNameFieldName         = "name"
+	hcEnvBootstrapHelmChartFieldName        = "chart"
+	hcEnvBootstrapHelmRepositoryFieldName   = "repository"
+	hcEnvBootstrapHelmVersionFieldName      = "version"
+	hcEnvBootstrapHelmNamespaceFieldName    = "namespace"
+	hcEnvBootstrapHelmValuesFieldName       = "values"
+	hcEnvBootstrapSecretNameFieldName       = "name"
+	hcEnvBootstrapSecretNamespaceFieldName  = "namespace"
+	hcEnvBootstrapSecretTypeFieldName       = "type"
+	hcEnvBootstrapSecretDataFieldName       = "data"
+	secretTypeOpaque                        = "Opaque"
+	secretTypeDockerConfigJSON              = "kubernetes.io/dockerconfigjson"
+	secretDockerConfigJSONKey               = ".dockerconfigjson"
+	hcEnvBootstrapHelmReleaseFieldsTemplate = "Helm release %s (as used by the helm_release resource)"
+)
+
+// helmValueFlags contains the helm flags which take a value (all other flags are considered booleans).
+var helmValueFlags = map[string]bool{
+	"namespace": true, "n": true, "version": true, "repo": true, "set": true, "set-string": true, "set-json": true, "set-file": true,
+	"values": true, "f": true, "timeout": true, "kube-context": true, "kubeconfig": true, "username": true, "password": true,
+	"description": true, "post-renderer": true,
+}
+
+// kubectlValueFlags contains the kubectl flags which take a value (all other flags are considered booleans).
+var kubectlValueFlags = map[string]bool{
+	"namespace": true, "n": true, "context": true, "kubeconfig": true, "filename": true, "f": true, "output": true, "o": true,
+	"from-literal": true, "from-file": true, "docker-server": true, "docker-username": true, "docker-password": true,
+	"docker-email": true, "cert": true, "key": true, "type": true,
+}
+
+// hcEnvBootstrap contains the structured resources of the bootstrap commands of a hybrid cloud environment.
+type hcEnvBootstrap struct {
+	Namespace    string
+	HelmReleases []hcEnvHelmRelease
+	Secrets      []hcEnvSecret
+	Manifests    []string
+}
+
+// hcEnvHelmRelease is a helm release installed by the bootstrap commands.
+type hcEnvHelmRelease struct {
+	Name       string
+	Chart      string
+	Repository string
+	Version    string
+	Namespace  string
+	Values     map[string]string
+}
+
+// hcEnvSecret is a Kubernetes secret created by the bootstrap commands.
+type hcEnvSecret struct {
+	Name      string
+	Namespace string
+	Type      string
+	Data      map[string]string
+}
+
+// accountsHybridCloudEnvironmentBootstrapSchema defines the schema for the structured bootstrap resources.
+func accountsHybridCloudEnvironmentBootstrapSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		hcEnvBootstrapNamespaceFieldName: {
+			Description: "The Kubernetes namespace the components are installed in",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvBootstrapHelmReleasesFieldName: {
+			Description: "The Helm releases to install (in order)",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					hcEnvBootstrapHelmNameFieldName: {
+						Description: fmt.Sprintf(hcEnvBootstrapHelmReleaseFieldsTemplate, "name"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					hcEnvBootstrapHelmChartFieldName: {
+						Description: fmt.Sprintf(hcEnvBootstrapHelmReleaseFieldsTemplate, "chart name"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					hcEnvBootstrapHelmRepositoryFieldName: {
+						Description: fmt.Sprintf(hcEnvBootstrapHelmReleaseFieldsTemplate, "chart repository (e.g. an oci:// URL)"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					hcEnvBootstrapHelmVersionFieldName: {
+						Description: fmt.Sprintf(hcEnvBootstrapHelmReleaseFieldsTemplate, "chart version"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					hcEnvBootstrapHelmNamespaceFieldName: {
+						Description: fmt.Sprintf(hcEnvBootstrapHelmReleaseFieldsTemplate, "namespace"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					hcEnvBootstrapHelmValuesFieldName: {
+						Description: fmt.Sprintf(hcEnvBootstrapHelmReleaseFieldsTemplate, "values, keyed by their (dotted) name, as provided by --set"),
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		hcEnvBootstrapSecretsFieldName: {
+			Description: "The Kubernetes secrets to create (e.g. the registry and agent credentials)",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					hcEnvBootstrapSecretNameFieldName: {
+						Description: "The name of the secret",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					hcEnvBootstrapSecretNamespaceFieldName: {
+						Description: "The namespace of the secret",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					hcEnvBootstrapSecretTypeFieldName: {
+						Description: "The type of the secret (e.g. Opaque or kubernetes.io/dockerconfigjson)",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					hcEnvBootstrapSecretDataFieldName: {
+						Description: "The (plain text) data of the secret",
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		hcEnvBootstrapManifestsFieldName: {
+			Description: "The Kubernetes manifests to apply (in order) as YAML documents, including the namespace and secrets (usable with yamldecode and the kubernetes_manifest resource)",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+// parseHCEnvBootstrapCommands parses the bootstrap commands (kubectl and helm) into structured resources.
+// Commands which are not recognized are ignored, they are still available in bootstrap_commands.
+func parseHCEnvBootstrapCommands(commands []string) (hcEnvBootstrap, error) {
+	var result hcEnvBootstrap
+	for _, command := range commands {
+		firstLine, heredoc := splitHeredoc(command)
+		args, err := splitShellWords(firstLine)
+		if err != nil {
+			return result, fmt.Errorf("error parsing bootstrap command: %w", err)
+		}
+		// Skip leading pipes like "cat <<EOF | kubectl apply -f -"
+		for i, arg := range args {
+			if arg == "|" {
+				args = args[i+1:]
+				break
+			}
+		}
+		if len(args) == 0 {
+			continue
+		}
+		switch path.Base(args[0]) {
+		case "kubectl":
+			if err := result.parseKubectl(args[1:], heredoc); err != nil {
+				return result, err
+			}
+		case "helm":
+			result.parseHelm(args[1:])
+		}
+	}
+	return result, nil
+}
+
+// parseKubectl parses a kubectl command (without "kubectl").
+func (b *hcEnvBootstrap) parseKubectl(args []string, heredoc string) error {
+	positional, flags := parseCommandFlags(args, kubectlValueFlags)
+	namespace := flagValue(flags, "namespace", "n")
+	switch {
+	case len(positional) >= 3 && positional[0] == "create" && (positional[1] == "namespace" || positional[1] == "ns"):
+		b.Namespace = positional[2]
+		b.Manifests = append(b.Manifests, renderManifest(yaml.MapSlice{
+			{Key: "apiVersion", Value: "v1"},
+			{Key: "kind", Value: "Namespace"},
+			{Key: "metadata", Value: yaml.MapSlice{{Key: "name", Value: positional[2]}}},
+		}))
+	case len(positional) >= 4 && positional[0] == "create" && positional[1] == "secret":
+		secret, err := newHCEnvSecret(positional[2], positional[3], namespace, flags)
+		if err != nil {
+			return err
+		}
+		b.Secrets = append(b.Secrets, secret)
+		b.Manifests = append(b.Manifests, renderSecretManifest(secret))
+	case len(positional) >= 1 && positional[0] == "apply" && heredoc != "":
+		b.Manifests = append(b.Manifests, splitYAMLDocuments(heredoc)...)
+	}
+	return nil
+}
+
+// parseHelm parses a helm install or upgrade --install command (without "helm").
+func (b *hcEnvBootstrap) parseHelm(args []string) {
+	positional, flags := parseCommandFlags(args, helmValueFlags)
+	if len(positional) < 3 || (positional[0] != "install" && positional[0] != "upgrade") {
+		return
+	}
+	release := hcEnvHelmRelease{
+		Name:       positional[1],
+		Chart:      positional[2],
+		Repository: flagValue(flags, "repo"),
+		Version:    flagValue(flags, "version"),
+		Namespace:  flagValue(flags, "namespace", "n"),
+		Values:     map[string]string{},
+	}
+	// OCI charts are split into the repository and the chart name, as expected by the helm_release resource.
+	if strings.HasPrefix(release.Chart, "oci://") && release.Repository == "" {
+		if i := strings.LastIndex(release.Chart, "/"); i > len("oci://") {
+			release.Repository, release.Chart = release.Chart[:i], release.Chart[i+1:]
+		}
+	}
+	for _, flag := range []string{"set", "set-string"} {
+		for _, v := range flags[flag] {
+			for _, item := range splitHelmSetValues(v) {
+				if key, value, ok := strings.Cut(item, "="); ok {
+					release.Values[key] = value
+				}
+			}
+		}
+	}
+	b.HelmReleases = append(b.HelmReleases, release)
+}
+
+// newHCEnvSecret creates a secret from the arguments of "kubectl create secret <type> <name>".
+func newHCEnvSecret(secretType, name, namespace string, flags map[string][]string) (hcEnvSecret, error) {
+	secret := hcEnvSecret{Name: name, Namespace: namespace, Data: map[string]string{}}
+	switch secretType {
+	case "generic":
+		secret.Type = flagValue(flags, "type")
+		if secret.Type == "" {
+			secret.Type = secretTypeOpaque
+		}
+		for _, literal := range flags["from-literal"] {
+			key, value, ok := strings.Cut(literal, "=")
+			if !ok {
+				return secret, fmt.Errorf("invalid --from-literal %q of secret %s, expected key=value", literal, name)
+			}
+			secret.Data[key] = value
+		}
+	case "docker-registry":
+		secret.Type = secretTypeDockerConfigJSON
+		server := flagValue(flags, "docker-server")
+		username := flagValue(flags, "docker-username")
+		password := flagValue(flags, "docker-password")
+		auth := map[string]string{
+			"username": username,
+			"password": password,
+			"auth":     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+		}
+		if email := flagValue(flags, "docker-email"); email != "" {
+			auth["email"] = email
+		}
+		config, err := json.Marshal(map[string]interface{}{"auths": map[string]interface{}{server: auth}})
+		if err != nil {
+			return secret, fmt.Errorf("error creating docker config of secret %s: %w", name, err)
+		}
+		secret.Data[secretDockerConfigJSONKey] = string(config)
+	default:
+		return secret, fmt.Errorf("unsupported secret type %q of secret %s", secretType, name)
+	}
+	return secret, nil
+}
+
+// renderSecretManifest renders the secret as a YAML manifest (using stringData, so no base64 encoding is needed).
+func renderSecretManifest(secret hcEnvSecret) string {
+	metadata := yaml.MapSlice{{Key: "name", Value: secret.Name}}
+	if secret.Namespace != "" {
+		metadata = append(metadata, yaml.MapItem{Key: "namespace", Value: secret.Namespace})
+	}
+	keys := make([]string, 0, len(secret.Data))
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	data := yaml.MapSlice{}
+	for _, k := range keys {
+		data = append(data, yaml.MapItem{Key: k, Value: secret.Data[k]})
+	}
+	return renderManifest(yaml.MapSlice{
+		{Key: "apiVersion", Value: "v1"},
+		{Key: "kind", Value: "Secret"},
+		{Key: "metadata", Value: metadata},
+		{Key: "type", Value: secret.Type},
+		{Key: "stringData", Value: data},
+	})
+}
+
+// renderManifest renders the provided (ordered) manifest as a YAML document.
+func renderManifest(manifest yaml.MapSlice) string {
+	out, err := yaml.Marshal(manifest)
+	if err != nil {
+		// Marshalling plain strings and maps cannot fail.
+		return ""
+	}
+	return string(out)
+}
+
+// parseCommandFlags splits the arguments of a command in positional arguments and flags.
+// Flags can be provided as "--flag value", "--flag=value" or "-f value", the value flags are used to know
+// which flags take a value. Flags can be repeated, so the values are returned as a list.
+func parseCommandFlags(args []string, valueFlags map[string]bool) ([]string, map[string][]string) {
+	var positional []string
+	flags := map[string][]string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		if k, v, ok := strings.Cut(name, "="); ok {
+			flags[k] = append(flags[k], v)
+			continue
+		}
+		if valueFlags[name] && i+1 < len(args) {
+			flags[name] = append(flags[name], args[i+1])
+			i++
+			continue
+		}
+		flags[name] = append(flags[name], "true")
+	}
+	return positional, flags
+}
+
+// flagValue returns the last value of the first provided flag name which is set (or empty if none is set).
+func flagValue(flags map[string][]string, names ...string) string {
+	for _, name := range names {
+		if values := flags[name]; len(values) > 0 {
+			return values[len(values)-1]
+		}
+	}
+	return ""
+}
+
+// splitHelmSetValues splits the value of a --set flag on unescaped commas (e.g. "a=1,b=2" into "a=1" and "b=2").
+func splitHelmSetValues(v string) []string {
+	var result []string
+	var current strings.Builder
+	for i := 0; i < len(v); i++ {
+		switch {
+		case v[i] == '\\' && i+1 < len(v) && v[i+1] == ',':
+			current.WriteByte(',')
+			i++
+		case v[i] == ',':
+			result = append(result, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(v[i])
+		}
+	}
+	return append(result, current.String())
+}
+
+// splitHeredoc splits a command in its first line and the body of its heredoc (if any), e.g.
+// "kubectl apply -f - <<EOF\n...\nEOF". Line continuations are joined into the first line.
+func splitHeredoc(command string) (string, string) {
+	command = strings.ReplaceAll(command, "\\\n", " ")
+	firstLine, rest, _ := strings.Cut(command, "\n")
+	i := strings.Index(firstLine, "<<")
+	if i < 0 {
+		return strings.TrimSpace(firstLine), ""
+	}
+	// The marker is the word following "<<" (or "<<-"), optionally quoted.
+	words := strings.Fields(strings.TrimPrefix(firstLine[i+2:], "-"))
+	if len(words) == 0 {
+		return strings.TrimSpace(firstLine), ""
+	}
+	marker := strings.Trim(words[0], `'"`)
+	firstLine = firstLine[:i] + " " + strings.Join(words[1:], " ")
+	var body []string
+	for _, line := range strings.Split(rest, "\n") {
+		if strings.TrimSpace(line) == marker {
+			break
+		}
+		body = append(body, line)
+	}
+	return strings.TrimSpace(firstLine), strings.Join(body, "\n")
+}
+
+// splitYAMLDocuments splits a multi-document YAML string into its (non-empty) documents.
+func splitYAMLDocuments(s string) []string {
+	var result []string
+	for _, doc := range strings.Split("\n"+s, "\n---") {
+		if doc = strings.TrimSpace(doc); doc != "" {
+			result = append(result, doc+"\n")
+		}
+	}
+	return result
+}
+
+// splitShellWords splits a command line into words, like a POSIX shell (supporting single and double quotes and escapes).
+func splitShellWords(s string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	inWord := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote in %q", s)
+			}
+			current.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(`"\$`+"`", s[i+1]) >= 0 {
+					i++
+				}
+				current.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("unterminated double quote in %q", s)
+			}
+			inWord = true
+		case c == '\\' && i+1 < len(s):
+			i++
+			current.WriteByte(s[i])
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
+
+// flattenHCEnvBootstrap creates a list (with a single item) from the bootstrap resources for easy storage in Terraform.
+func flattenHCEnvBootstrap(b hcEnvBootstrap) []interface{} {
+	helmReleases := make([]interface{}, len(b.HelmReleases))
+	for i, r := range b.HelmReleases {
+		helmReleases[i] = map[string]interface{}{
+			hcEnvBootstrapHelmNameFieldName:       r.Name,
+			hcEnvBootstrapHelmChartFieldName:      r.Chart,
+			hcEnvBootstrapHelmRepositoryFieldName: r.Repository,
+			hcEnvBootstrapHelmVersionFieldName:    r.Version,
+			hcEnvBootstrapHelmNamespaceFieldName:  r.Namespace,
+			hcEnvBootstrapHelmValuesFieldName:     flattenStringMap(r.Values),
+		}
+	}
+	secrets := make([]interface{}, len(b.Secrets))
+	for i, s := range b.Secrets {
+		secrets[i] = map[string]interface{}{
+			hcEnvBootstrapSecretNameFieldName:      s.Name,
+			hcEnvBootstrapSecretNamespaceFieldName: s.Namespace,
+			hcEnvBootstrapSecretTypeFieldName:      s.Type,
+			hcEnvBootstrapSecretDataFieldName:      flattenStringMap(s.Data),
+		}
+	}
+	manifests := make([]interface{}, len(b.Manifests))
+	for i, m := range b.Manifests {
+		manifests[i] = m
+	}
+	return []interface{}{map[string]interface{}{
+		hcEnvBootstrapNamespaceFieldName:    b.Namespace,
+		hcEnvBootstrapHelmReleasesFieldName: helmReleases,
+		hcEnvBootstrapSecretsFieldName:      secrets,
+		hcEnvBootstrapManifestsFieldName:    manifests,
+	}}
+}
+
+// flattenStringMap converts a string map into a map which can be stored in Terraform.
+func flattenStringMap(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package qdrant
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBootstrapCommands = []string{
+	"kubectl create namespace qdrant",
+	`kubectl --namespace qdrant create secret docker-registry qdrant-registry-creds --docker-server=registry.cloud.qdrant.io --docker-username='user-1' --docker-password='s3cr3t'`,
+	`kubectl -n qdrant create secret generic qdrant-cloud-creds --from-literal=access-key='key-1'`,
+	`helm upgrade --install qdrant-cloud-agent oci://registry.cloud.qdrant.io/qdrant-charts/qdrant-cloud-agent \
+  --namespace qdrant --version 1.8.0 --wait \
+  --set account_id=acc-1,cluster_id=env-1 \
+  --set-string "image.tag=v1.8.0"`,
+	`cat <<EOF | kubectl apply -f -
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+EOF`,
+	"echo done",
+}
+
+func TestParseHCEnvBootstrapCommands(t *testing.T) {
+	b, err := parseHCEnvBootstrapCommands(testBootstrapCommands)
+	require.NoError(t, err)
+
+	assert.Equal(t, "qdrant", b.Namespace)
+
+	require.Len(t, b.HelmReleases, 1)
+	assert.Equal(t, hcEnvHelmRelease{
+		Name:       "qdrant-cloud-agent",
+		Chart:      "qdrant-cloud-agent",
+		Repository: "oci://registry.cloud.qdrant.io/qdrant-charts",
+		Version:    "1.8.0",
+		Namespace:  "qdrant",
+		Values: map[string]string{
+			"account_id": "acc-1",
+			"cluster_id": "env-1",
+			"image.tag":  "v1.8.0",
+		},
+	}, b.HelmReleases[0])
+
+	require.Len(t, b.Secrets, 2)
+	assert.Equal(t, "qdrant-registry-creds", b.Secrets[0].Name)
+	assert.Equal(t, "qdrant", b.Secrets[0].Namespace)
+	assert.Equal(t, secretTypeDockerConfigJSON, b.Secrets[0].Type)
+	var dockerConfig struct {
+		Auths map[string]map[string]string `json:"auths"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(b.Secrets[0].Data[secretDockerConfigJSONKey]), &dockerConfig))
+	auth := dockerConfig.Auths["registry.cloud.qdrant.io"]
+	assert.Equal(t, "user-1", auth["username"])
+	assert.Equal(t, "s3cr3t", auth["password"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("user-1:s3cr3t")), auth["auth"])
+
+	assert.Equal(t, hcEnvSecret{
+		Name:      "qdrant-cloud-creds",
+		Namespace: "qdrant",
+		Type:      secretTypeOpaque,
+		Data:      map[string]string{"access-key": "key-1"},
+	}, b.Secrets[1])
+
+	// Namespace, 2 secrets and 2 applied documents
+	require.Len(t, b.Manifests, 5)
+	var ns map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(b.Manifests[0]), &ns))
+	assert.Equal(t, "Namespace", ns["kind"])
+	var secret map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(b.Manifests[2]), &secret))
+	assert.Equal(t, "Secret", secret["kind"])
+	assert.Equal(t, map[string]interface{}{"access-key": "key-1"}, secret["stringData"])
+	assert.Contains(t, b.Manifests[3], "name: first")
+	assert.Contains(t, b.Manifests[4], "name: second")
+}
+
+func TestParseHCEnvBootstrapCommandsErrors(t *testing.T) {
+	_, err := parseHCEnvBootstrapCommands([]string{`kubectl create secret generic s --from-literal='unterminated`})
+	assert.ErrorContains(t, err, "unterminated single quote")
+
+	_, err = parseHCEnvBootstrapCommands([]string{`kubectl create secret generic s --from-literal=novalue`})
+	assert.ErrorContains(t, err, "expected key=value")
+
+	_, err = parseHCEnvBootstrapCommands([]string{`kubectl create secret unknown s`})
+	assert.ErrorContains(t, err, "unsupported secret type")
+}
+
+func TestSplitShellWords(t *testing.T) {
+	words, err := splitShellWords(`helm install a 'b c' "d \"e\"" f\ g --set=x`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"helm", "install", "a", "b c", `d "e"`, "f g", "--set=x"}, words)
+}
+
+func TestSplitHelmSetValues(t *testing.T) {
+	assert.Equal(t, []string{"a=1", "b=2,3"}, splitHelmSetValues(`a=1,b=2\,3`))
+}
+
+func TestFlattenHCEnvBootstrap(t *testing.T) {
+	b, err := parseHCEnvBootstrapCommands(testBootstrapCommands)
+	require.NoError(t, err)
+	flattened := flattenHCEnvBootstrap(b)
+	require.Len(t, flattened, 1)
+	item := flattened[0].(map[string]interface{})
+	assert.Equal(t, "qdrant", item[hcEnvBootstrapNamespaceFieldName])
+	assert.Len(t, item[hcEnvBootstrapHelmReleasesFieldName], 1)
+	assert.Len(t, item[hcEnvBootstrapSecretsFieldName], 2)
+	assert.Len(t, item[hcEnvBootstrapManifestsFieldName], 5)
+}
@@ -0,0 +1,31 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// planHCEnvCACertificates plans the expiry and fingerprints of the CA certificates of the hybrid cloud environment
+// if they change, so they are known during plan (the certificates themselves are validated by the schema).
+func planHCEnvCACertificates(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	caCertificatesPath := fmt.Sprintf("%s.0.%s", hcEnvConfigurationFieldName, hcEnvCfgCaCertificatesFieldName)
+	if !d.HasChange(caCertificatesPath) {
+		return nil
+	}
+	if !d.NewValueKnown(caCertificatesPath) {
+		for _, k := range []string{hcEnvCaCertificatesNotAfterFieldName, hcEnvCaCertificatesFingerprintsFieldName} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	caCertificates, _ := d.Get(caCertificatesPath).(string)
+	notAfter, fingerprints := certificatesInfo(caCertificates)
+	if err := d.SetNew(hcEnvCaCertificatesNotAfterFieldName, notAfter); err != nil {
+		return err
+	}
+	return d.SetNew(hcEnvCaCertificatesFingerprintsFieldName, fingerprints)
+}
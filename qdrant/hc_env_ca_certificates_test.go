@@ -0,0 +1,45 @@
+package qdrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+func TestFlattenHCEnvCACertificates(t *testing.T) {
+	notAfter := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+	ca, _ := newTestCertificate(t, "ca", true, notAfter.AddDate(-1, 0, 0), notAfter)
+	env := &qch.HybridCloudEnvironment{
+		Id:            "env-1",
+		Configuration: &qch.HybridCloudEnvironmentConfiguration{CaCertificates: newPointer(ca)},
+	}
+	got := flattenHCEnv(env)
+	assert.Equal(t, "2027-06-01T00:00:00Z", got[hcEnvCaCertificatesNotAfterFieldName])
+	require.Len(t, got[hcEnvCaCertificatesFingerprintsFieldName], 1)
+
+	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(false), map[string]interface{}{})
+	for _, k := range []string{hcEnvCaCertificatesNotAfterFieldName, hcEnvCaCertificatesFingerprintsFieldName} {
+		require.NoError(t, d.Set(k, got[k]), k)
+	}
+	assert.Equal(t, got[hcEnvCaCertificatesFingerprintsFieldName].([]string)[0], d.Get(hcEnvCaCertificatesFingerprintsFieldName+".0"))
+}
+
+func TestHCEnvCACertificatesSchema(t *testing.T) {
+	cfg := accountsHybridCloudEnvironmentSchema(false)[hcEnvConfigurationFieldName].Elem.(*schema.Resource).Schema
+	assert.NotNil(t, cfg[hcEnvCfgCaCertificatesFieldName].ValidateDiagFunc)
+	// Data sources don't validate computed values
+	dsCfg := accountsHybridCloudEnvironmentSchema(true)[hcEnvConfigurationFieldName].Elem.(*schema.Resource).Schema
+	assert.Nil(t, dsCfg[hcEnvCfgCaCertificatesFieldName].ValidateDiagFunc)
+	for _, asDataSource := range []bool{false, true} {
+		s := accountsHybridCloudEnvironmentSchema(asDataSource)
+		for _, k := range []string{hcEnvCaCertificatesNotAfterFieldName, hcEnvCaCertificatesFingerprintsFieldName} {
+			require.Contains(t, s, k)
+			assert.True(t, s[k].Computed)
+		}
+	}
+}
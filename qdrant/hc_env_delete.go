@@ -0,0 +1,213 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	qcb "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+const (
+	hcEnvClustersDeletedPollInterval = 15 * time.Second
+	hcEnvDeleteTimeout               = 60 * time.Minute
+
+	hcEnvClustersWaitPending = "deleting"
+	hcEnvClustersWaitDeleted = "deleted"
+)
+
+// listHCEnvClusters returns the clusters hosted in the provided hybrid cloud environment,
+// i.e. the hybrid clusters with the environment ID as cloud region.
+func listHCEnvClusters(ctx context.Context, client qcCluster.ClusterServiceClient, accountID, envID string) ([]*qcCluster.Cluster, error) {
+	clusters, err := listClustersWithRequest(ctx, client, &qcCluster.ListClustersRequest{
+		AccountId:             accountID,
+		CloudProviderId:       newPointer(hybridCloudClusterID),
+		CloudProviderRegionId: newPointer(envID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterHCEnvClusters(clusters, envID), nil
+}
+
+// filterHCEnvClusters returns the clusters with the provided hybrid cloud environment ID as cloud region.
+func filterHCEnvClusters(clusters []*qcCluster.Cluster, envID string) []*qcCluster.Cluster {
+	var result []*qcCluster.Cluster
+	for _, cluster := range clusters {
+		if cluster.GetCloudProviderId() == hybridCloudClusterID && cluster.GetCloudProviderRegionId() == envID {
+			result = append(result, cluster)
+		}
+	}
+	return result
+}
+
+// blockingHCEnvClusters returns the clusters which are not being deleted already.
+func blockingHCEnvClusters(clusters []*qcCluster.Cluster) []*qcCluster.Cluster {
+	var result []*qcCluster.Cluster
+	for _, cluster := range clusters {
+		if cluster.GetDeletedAt() == nil {
+			result = append(result, cluster)
+		}
+	}
+	return result
+}
+
+// describeHCEnvClusters returns a human readable list of the clusters (sorted by name), e.g. "prod (id-1), test (id-2)".
+func describeHCEnvClusters(clusters []*qcCluster.Cluster) string {
+	items := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		items = append(items, fmt.Sprintf("%s (%s)", cluster.GetName(), cluster.GetId()))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ", ")
+}
+
+// deleteHCEnvClusters deletes the provided clusters, keeping their backups.
+// Clusters having a backup schedule get a final backup (awaited until the deadline) before they are deleted.
+// The final_backup and delete_backups_on_destroy settings of cluster resources are not applied,
+// as they are part of the Terraform state of those resources, which isn't available to the environment.
+// Final backups are logged, an error is returned if a backup or deletion failed.
+func deleteHCEnvClusters(
+	ctx context.Context,
+	client qcCluster.ClusterServiceClient,
+	backupClient qcb.BackupServiceClient,
+	clientCtx context.Context,
+	accountID string,
+	clusters []*qcCluster.Cluster,
+	deadline time.Time,
+) diag.Diagnostics {
+	for _, cluster := range clusters {
+		var trailer metadata.MD
+		schedules, err := backupClient.ListBackupSchedules(clientCtx, &qcb.ListBackupSchedulesRequest{
+			AccountId: accountID,
+			ClusterId: newPointer(cluster.GetId()),
+		}, grpc.Trailer(&trailer))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error listing backup schedules of cluster %s%s: %w", cluster.GetId(), getRequestID(trailer), err))
+		}
+		if len(schedules.GetItems()) > 0 {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return diag.Errorf("timeout while deleting the clusters of the hybrid cloud environment, no time left for the final backup of cluster %s", cluster.GetId())
+			}
+			backup, err := createBackupAndWait(ctx, backupClient, clientCtx, accountID, cluster.GetId(), nil, remaining)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error creating final backup of cluster %s: %w", cluster.GetId(), err))
+			}
+			tflog.Info(ctx, "Final backup of the cluster created before deletion", map[string]interface{}{
+				"cluster_id": cluster.GetId(),
+				"backup_id":  backup.GetId(),
+			})
+		}
+		_, err = client.DeleteCluster(clientCtx, &qcCluster.DeleteClusterRequest{
+			AccountId:     accountID,
+			ClusterId:     cluster.GetId(),
+			DeleteBackups: newPointer(false),
+		}, grpc.Trailer(&trailer))
+		if err != nil {
+			// If the cluster is not found, it has been deleted already.
+			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+				continue
+			}
+			return diag.FromErr(fmt.Errorf("error deleting cluster %s%s: %w", cluster.GetId(), getRequestID(trailer), err))
+		}
+	}
+	return nil
+}
+
+// hcEnvClustersDeletedRefreshFunc returns a StateRefreshFunc that lists the clusters of the hybrid cloud environment
+// until none is left. The remaining clusters are stored in remaining, so they can be reported if the wait times out.
+func hcEnvClustersDeletedRefreshFunc(
+	client qcCluster.ClusterServiceClient,
+	ctx context.Context,
+	accountID, envID string,
+	remaining *[]*qcCluster.Cluster,
+) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		clusters, err := listHCEnvClusters(ctx, client, accountID, envID)
+		if err != nil {
+			return nil, "", err
+		}
+		*remaining = clusters
+		if len(clusters) > 0 {
+			return clusters, hcEnvClustersWaitPending, nil
+		}
+		return clusters, hcEnvClustersWaitDeleted, nil
+	}
+}
+
+// waitForHCEnvClustersDeleted waits until the hybrid cloud environment hosts no clusters anymore.
+func waitForHCEnvClustersDeleted(
+	ctx context.Context,
+	client qcCluster.ClusterServiceClient,
+	clientCtx context.Context,
+	accountID, envID string,
+	timeout time.Duration,
+) error {
+	var remaining []*qcCluster.Cluster
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{hcEnvClustersWaitPending},
+		Target:       []string{hcEnvClustersWaitDeleted},
+		Refresh:      hcEnvClustersDeletedRefreshFunc(client, clientCtx, accountID, envID, &remaining),
+		Timeout:      timeout,
+		PollInterval: hcEnvClustersDeletedPollInterval,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		if len(remaining) > 0 {
+			return fmt.Errorf("waiting for the clusters of hybrid cloud environment %s to be deleted, remaining: %s: %w",
+				envID, describeHCEnvClusters(remaining), err)
+		}
+		return fmt.Errorf("waiting for the clusters of hybrid cloud environment %s to be deleted: %w", envID, err)
+	}
+	return nil
+}
+
+// guardHCEnvClusters refuses to delete a hybrid cloud environment which still hosts clusters, unless force_destroy is set,
+// in which case the clusters are deleted first. Waits until all clusters (including those already being deleted) are gone.
+// The timeout covers the whole operation (final backups, deletion and waiting), not every step.
+func guardHCEnvClusters(
+	ctx context.Context,
+	client qcCluster.ClusterServiceClient,
+	backupClient qcb.BackupServiceClient,
+	clientCtx context.Context,
+	accountID, envID string,
+	forceDestroy bool,
+	timeout time.Duration,
+) diag.Diagnostics {
+	deadline := time.Now().Add(timeout)
+	clusters, err := listHCEnvClusters(clientCtx, client, accountID, envID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(clusters) == 0 {
+		return nil
+	}
+	if blocking := blockingHCEnvClusters(clusters); len(blocking) > 0 {
+		if !forceDestroy {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Hybrid cloud environment still hosts clusters",
+				Detail: fmt.Sprintf("Hybrid cloud environment %s hosts %d cluster(s): %s. Delete them first, or set %s = true (and apply) to delete them with the environment.",
+					envID, len(blocking), describeHCEnvClusters(blocking), hcEnvForceDestroyFieldName),
+			}}
+		}
+		if diags := deleteHCEnvClusters(ctx, client, backupClient, clientCtx, accountID, blocking, deadline); diags.HasError() {
+			return diags
+		}
+	}
+	if err := waitForHCEnvClustersDeleted(ctx, client, clientCtx, accountID, envID, time.Until(deadline)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package qdrant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	qcb "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+// mockHCEnvClusterClient returns the list responses in order (repeating the last one) and records the deleted clusters.
+type mockHCEnvClusterClient struct {
+	qcCluster.ClusterServiceClient
+	lists     [][]*qcCluster.Cluster
+	listCalls int
+	listReq   *qcCluster.ListClustersRequest
+	deleted   []*qcCluster.DeleteClusterRequest
+}
+
+func (m *mockHCEnvClusterClient) ListClusters(_ context.Context, in *qcCluster.ListClustersRequest, _ ...grpc.CallOption) (*qcCluster.ListClustersResponse, error) {
+	m.listReq = in
+	idx := m.listCalls
+	m.listCalls++
+	if idx >= len(m.lists) {
+		idx = len(m.lists) - 1
+	}
+	return &qcCluster.ListClustersResponse{Items: m.lists[idx]}, nil
+}
+
+func (m *mockHCEnvClusterClient) DeleteCluster(_ context.Context, in *qcCluster.DeleteClusterRequest, _ ...grpc.CallOption) (*qcCluster.DeleteClusterResponse, error) {
+	m.deleted = append(m.deleted, in)
+	return &qcCluster.DeleteClusterResponse{}, nil
+}
+
+// mockHCEnvBackupClient adds backup schedules (per cluster ID) to mockBackupServiceClient.
+type mockHCEnvBackupClient struct {
+	*mockBackupServiceClient
+	schedules map[string][]*qcb.BackupSchedule
+}
+
+func (m *mockHCEnvBackupClient) ListBackupSchedules(_ context.Context, in *qcb.ListBackupSchedulesRequest, _ ...grpc.CallOption) (*qcb.ListBackupSchedulesResponse, error) {
+	return &qcb.ListBackupSchedulesResponse{Items: m.schedules[in.GetClusterId()]}, nil
+}
+
+func hcEnvTestCluster(id, name, region string) *qcCluster.Cluster {
+	return &qcCluster.Cluster{Id: id, Name: name, CloudProviderId: hybridCloudClusterID, CloudProviderRegionId: region}
+}
+
+func newMockHCEnvBackupClient(schedules map[string][]*qcb.BackupSchedule) *mockHCEnvBackupClient {
+	return &mockHCEnvBackupClient{
+		mockBackupServiceClient: &mockBackupServiceClient{statuses: []qcb.BackupStatus{qcb.BackupStatus_BACKUP_STATUS_SUCCEEDED}},
+		schedules:               schedules,
+	}
+}
+
+func TestFilterHCEnvClusters(t *testing.T) {
+	clusters := []*qcCluster.Cluster{
+		hcEnvTestCluster("c1", "a", "env-1"),
+		hcEnvTestCluster("c2", "b", "env-2"),
+		{Id: "c3", Name: "c", CloudProviderId: "aws", CloudProviderRegionId: "env-1"},
+	}
+	got := filterHCEnvClusters(clusters, "env-1")
+	require.Len(t, got, 1)
+	assert.Equal(t, "c1", got[0].GetId())
+}
+
+func TestDescribeHCEnvClusters(t *testing.T) {
+	assert.Equal(t, "a (c2), b (c1)", describeHCEnvClusters([]*qcCluster.Cluster{
+		hcEnvTestCluster("c1", "b", "env-1"),
+		hcEnvTestCluster("c2", "a", "env-1"),
+	}))
+}
+
+func TestGuardHCEnvClusters(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no clusters", func(t *testing.T) {
+		client := &mockHCEnvClusterClient{lists: [][]*qcCluster.Cluster{nil}}
+		diags := guardHCEnvClusters(ctx, client, newMockHCEnvBackupClient(nil), ctx, "acc-1", "env-1", false, time.Minute)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, hybridCloudClusterID, client.listReq.GetCloudProviderId())
+		assert.Equal(t, "env-1", client.listReq.GetCloudProviderRegionId())
+		assert.Empty(t, client.deleted)
+	})
+	t.Run("clusters without force_destroy", func(t *testing.T) {
+		client := &mockHCEnvClusterClient{lists: [][]*qcCluster.Cluster{{
+			hcEnvTestCluster("c1", "prod", "env-1"),
+			hcEnvTestCluster("c2", "dev", "env-1"),
+		}}}
+		diags := guardHCEnvClusters(ctx, client, newMockHCEnvBackupClient(nil), ctx, "acc-1", "env-1", false, time.Minute)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Detail, "hosts 2 cluster(s): dev (c2), prod (c1)")
+		assert.Contains(t, diags[0].Detail, hcEnvForceDestroyFieldName)
+		assert.Empty(t, client.deleted)
+	})
+	t.Run("clusters with force_destroy", func(t *testing.T) {
+		client := &mockHCEnvClusterClient{lists: [][]*qcCluster.Cluster{
+			{hcEnvTestCluster("c1", "prod", "env-1"), hcEnvTestCluster("c2", "dev", "env-1")},
+			nil,
+		}}
+		backupClient := newMockHCEnvBackupClient(map[string][]*qcb.BackupSchedule{
+			"c1": {{Id: "schedule-1", ClusterId: "c1"}},
+		})
+		diags := guardHCEnvClusters(ctx, client, backupClient, ctx, "acc-1", "env-1", true, time.Minute)
+		require.False(t, diags.HasError())
+		// The cluster with a backup schedule got a final backup, which is logged (not reported as a warning)
+		assert.Empty(t, diags)
+		require.NotNil(t, backupClient.created)
+		assert.Equal(t, "c1", backupClient.created.GetClusterId())
+		// Both clusters have been deleted, keeping their backups
+		require.Len(t, client.deleted, 2)
+		for _, req := range client.deleted {
+			assert.False(t, req.GetDeleteBackups())
+		}
+		assert.Equal(t, 2, client.listCalls)
+	})
+	t.Run("timeout shared by the final backups", func(t *testing.T) {
+		client := &mockHCEnvClusterClient{lists: [][]*qcCluster.Cluster{{hcEnvTestCluster("c1", "prod", "env-1")}}}
+		backupClient := newMockHCEnvBackupClient(map[string][]*qcb.BackupSchedule{
+			"c1": {{Id: "schedule-1", ClusterId: "c1"}},
+		})
+		diags := guardHCEnvClusters(ctx, client, backupClient, ctx, "acc-1", "env-1", true, 0)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "no time left for the final backup of cluster c1")
+		assert.Nil(t, backupClient.created)
+		assert.Empty(t, client.deleted)
+	})
+	t.Run("clusters being deleted already don't block", func(t *testing.T) {
+		deleting := hcEnvTestCluster("c1", "prod", "env-1")
+		deleting.DeletedAt = timestamppb.Now()
+		client := &mockHCEnvClusterClient{lists: [][]*qcCluster.Cluster{{deleting}, nil}}
+		diags := guardHCEnvClusters(ctx, client, newMockHCEnvBackupClient(nil), ctx, "acc-1", "env-1", false, time.Minute)
+		assert.False(t, diags.HasError())
+		assert.Empty(t, client.deleted)
+		assert.Equal(t, 2, client.listCalls)
+	})
+}
+
+func TestHCEnvClustersDeletedRefreshFunc(t *testing.T) {
+	client := &mockHCEnvClusterClient{lists: [][]*qcCluster.Cluster{
+		{hcEnvTestCluster("c1", "prod", "env-1")},
+		nil,
+	}}
+	var remaining []*qcCluster.Cluster
+	refresh := hcEnvClustersDeletedRefreshFunc(client, context.Background(), "acc-1", "env-1", &remaining)
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	assert.Equal(t, hcEnvClustersWaitPending, state)
+	assert.Len(t, remaining, 1)
+
+	_, state, err = refresh()
+	require.NoError(t, err)
+	assert.Equal(t, hcEnvClustersWaitDeleted, state)
+	assert.Empty(t, remaining)
+}
+
+func TestHCEnvForceDestroySchema(t *testing.T) {
+	s := accountsHybridCloudEnvironmentSchema(false)
+	require.Contains(t, s, hcEnvForceDestroyFieldName)
+	assert.True(t, s[hcEnvForceDestroyFieldName].Optional)
+	timeouts := resourceAccountsHybridCloudEnvironment().Timeouts
+	require.NotNil(t, timeouts.Delete)
+	assert.Equal(t, hcEnvDeleteTimeout, *timeouts.Delete)
+}
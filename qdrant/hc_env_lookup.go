@@ -0,0 +1,57 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+// listHCEnvs returns all hybrid cloud environments of the provided account.
+func listHCEnvs(ctx context.Context, client qch.HybridCloudServiceClient, accountID string) ([]*qch.HybridCloudEnvironment, error) {
+	var trailer metadata.MD
+	resp, err := client.ListHybridCloudEnvironments(ctx, &qch.ListHybridCloudEnvironmentsRequest{
+		AccountId: accountID,
+	}, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, fmt.Errorf("error listing hybrid cloud environments%s: %w", getRequestID(trailer), err)
+	}
+	return resp.GetItems(), nil
+}
+
+// matchHCEnvs returns the hybrid cloud environments with the provided name and status phase (if not empty).
+func matchHCEnvs(envs []*qch.HybridCloudEnvironment, name, phase string) []*qch.HybridCloudEnvironment {
+	var result []*qch.HybridCloudEnvironment
+	for _, env := range envs {
+		if name != "" && env.GetName() != name {
+			continue
+		}
+		if phase != "" && env.GetStatus().GetPhase().String() != phase {
+			continue
+		}
+		result = append(result, env)
+	}
+	return result
+}
+
+// selectSingleHCEnv returns the single matching hybrid cloud environment, or an error if none or multiple environments match the name.
+func selectSingleHCEnv(matches []*qch.HybridCloudEnvironment, name string) (*qch.HybridCloudEnvironment, error) {
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no hybrid cloud environment found with %s = %q", hcEnvNameFieldName, name)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, env := range matches {
+			ids = append(ids, env.GetId())
+		}
+		sort.Strings(ids)
+		return nil, fmt.Errorf("%d hybrid cloud environments found with %s = %q (%s), please use the id instead", len(matches), hcEnvNameFieldName, name, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,76 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+func (m *mockHybridCloudServiceClient) ListHybridCloudEnvironments(_ context.Context, _ *qch.ListHybridCloudEnvironmentsRequest, _ ...grpc.CallOption) (*qch.ListHybridCloudEnvironmentsResponse, error) {
+	m.callCount++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &qch.ListHybridCloudEnvironmentsResponse{Items: m.envs}, nil
+}
+
+func newLookupTestHCEnvs() []*qch.HybridCloudEnvironment {
+	ready := &qch.HybridCloudEnvironmentStatus{Phase: qch.HybridCloudEnvironmentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_READY}
+	notReady := &qch.HybridCloudEnvironmentStatus{Phase: qch.HybridCloudEnvironmentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_NOT_READY}
+	return []*qch.HybridCloudEnvironment{
+		{Id: "e1", Name: "prod", Status: ready},
+		{Id: "e2", Name: "staging", Status: notReady},
+		{Id: "e3", Name: "dev", Status: ready},
+		{Id: "e4", Name: "dev"},
+	}
+}
+
+func hcEnvIDs(envs []*qch.HybridCloudEnvironment) []string {
+	var ids []string
+	for _, env := range envs {
+		ids = append(ids, env.GetId())
+	}
+	return ids
+}
+
+func TestListHCEnvs(t *testing.T) {
+	client := &mockHybridCloudServiceClient{envs: newLookupTestHCEnvs()}
+	envs, err := listHCEnvs(context.Background(), client, "acc-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e1", "e2", "e3", "e4"}, hcEnvIDs(envs))
+
+	client = &mockHybridCloudServiceClient{err: errors.New("unavailable")}
+	_, err = listHCEnvs(context.Background(), client, "acc-1")
+	assert.EqualError(t, err, "error listing hybrid cloud environments: unavailable")
+}
+
+func TestMatchHCEnvs(t *testing.T) {
+	envs := newLookupTestHCEnvs()
+
+	assert.Equal(t, []string{"e1", "e2", "e3", "e4"}, hcEnvIDs(matchHCEnvs(envs, "", "")))
+	assert.Equal(t, []string{"e1"}, hcEnvIDs(matchHCEnvs(envs, "prod", "")))
+	assert.Equal(t, []string{"e3", "e4"}, hcEnvIDs(matchHCEnvs(envs, "dev", "")))
+	assert.Equal(t, []string{"e1", "e3"}, hcEnvIDs(matchHCEnvs(envs, "", "HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_READY")))
+	assert.Equal(t, []string{"e2"}, hcEnvIDs(matchHCEnvs(envs, "", "HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_NOT_READY")))
+	assert.Empty(t, matchHCEnvs(envs, "prod", "HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_NOT_READY"))
+}
+
+func TestSelectSingleHCEnv(t *testing.T) {
+	envs := newLookupTestHCEnvs()
+
+	env, err := selectSingleHCEnv(matchHCEnvs(envs, "prod", ""), "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "e1", env.GetId())
+
+	_, err = selectSingleHCEnv(matchHCEnvs(envs, "missing", ""), "missing")
+	assert.EqualError(t, err, `no hybrid cloud environment found with name = "missing"`)
+
+	_, err = selectSingleHCEnv(matchHCEnvs(envs, "dev", ""), "dev")
+	assert.EqualError(t, err, `2 hybrid cloud environments found with name = "dev" (e3, e4), please use the id instead`)
+}
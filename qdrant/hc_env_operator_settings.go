@@ -0,0 +1,347 @@
+package qdrant
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	hcEnvCfgOperatorSettingsFieldName = "operator_settings"
+
+	hcEnvOpFeatureFlagsFieldName         = "feature_flags"
+	hcEnvOpReconcileConcurrencyFieldName = "reconcile_concurrency"
+	hcEnvOpLogFormatFieldName            = "log_format"
+	hcEnvOpResourcesFieldName            = "resources"
+	hcEnvOpExtraYamlFieldName            = "extra_yaml"
+
+	hcEnvOpResLimitsCpuFieldName      = "limits_cpu"
+	hcEnvOpResLimitsMemoryFieldName   = "limits_memory"
+	hcEnvOpResRequestsCpuFieldName    = "requests_cpu"
+	hcEnvOpResRequestsMemoryFieldName = "requests_memory"
+
+	// Keys of the documented operator settings in the advanced operator settings document.
+	hcEnvOpFeaturesKey             = "features"
+	hcEnvOpReconcileConcurrencyKey = "reconcileConcurrency"
+	hcEnvOpLogFormatKey            = "logFormat"
+	hcEnvOpResourcesKey            = "resources"
+	hcEnvOpLimitsKey               = "limits"
+	hcEnvOpRequestsKey             = "requests"
+	hcEnvOpCpuKey                  = "cpu"
+	hcEnvOpMemoryKey               = "memory"
+)
+
+var hcEnvOpLogFormats = []string{"json", "console"}
+
+// hcEnvOpResourceFields maps the resources schema fields to their (section, key) in the settings document.
+var hcEnvOpResourceFields = map[string][2]string{
+	hcEnvOpResLimitsCpuFieldName:      {hcEnvOpLimitsKey, hcEnvOpCpuKey},
+	hcEnvOpResLimitsMemoryFieldName:   {hcEnvOpLimitsKey, hcEnvOpMemoryKey},
+	hcEnvOpResRequestsCpuFieldName:    {hcEnvOpRequestsKey, hcEnvOpCpuKey},
+	hcEnvOpResRequestsMemoryFieldName: {hcEnvOpRequestsKey, hcEnvOpMemoryKey},
+}
+
+// accountsHybridCloudEnvironmentOperatorSettingsSchema defines the typed operator settings block.
+func accountsHybridCloudEnvironmentOperatorSettingsSchema(asDataSource bool) map[string]*schema.Schema {
+	maxItems := 1
+	if asDataSource {
+		// We should not set Max Items
+		maxItems = 0
+	}
+	reconcileConcurrency := &schema.Schema{
+		Description: "Number of resources the operator reconciles concurrently (`reconcileConcurrency`).",
+		Type:        schema.TypeInt,
+		Optional:    !asDataSource,
+		Computed:    true,
+	}
+	logFormat := &schema.Schema{
+		Description: fmt.Sprintf("Log format of the operator (`logFormat`). Must be one of: %s.", strings.Join(hcEnvOpLogFormats, ", ")),
+		Type:        schema.TypeString,
+		Optional:    !asDataSource,
+		Computed:    true,
+	}
+	extraYaml := &schema.Schema{
+		Description: "Additional operator settings as a YAML map. The typed settings in this block take precedence over the same keys in this document. " +
+			"Reordering keys or changing the formatting doesn't cause a diff.",
+		Type:     schema.TypeString,
+		Optional: !asDataSource,
+		Computed: true,
+	}
+	if !asDataSource {
+		reconcileConcurrency.ValidateDiagFunc = validation.ToDiagFunc(validation.IntAtLeast(1))
+		logFormat.ValidateDiagFunc = validation.ToDiagFunc(validation.StringInSlice(hcEnvOpLogFormats, false))
+		extraYaml.ValidateDiagFunc = validation.ToDiagFunc(validateYAMLMap)
+		extraYaml.DiffSuppressFunc = suppressEquivalentYAMLDiff
+		extraYaml.StateFunc = normalizeYAMLStateFunc
+	}
+	resourceField := func(description string) *schema.Schema {
+		s := &schema.Schema{
+			Description: description,
+			Type:        schema.TypeString,
+			Optional:    !asDataSource,
+			Computed:    true,
+		}
+		if !asDataSource {
+			s.ValidateDiagFunc = validation.ToDiagFunc(validateResourceQuantity)
+		}
+		return s
+	}
+
+	return map[string]*schema.Schema{
+		hcEnvOpFeatureFlagsFieldName: {
+			Description: "Operator feature flags to enable or disable (`features`).",
+			Type:        schema.TypeMap,
+			Optional:    !asDataSource,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeBool},
+		},
+		hcEnvOpReconcileConcurrencyFieldName: reconcileConcurrency,
+		hcEnvOpLogFormatFieldName:            logFormat,
+		hcEnvOpResourcesFieldName: {
+			Description: "Resource requests and limits of the operator (`resources`).",
+			Type:        schema.TypeList,
+			Optional:    !asDataSource,
+			Computed:    true,
+			MaxItems:    maxItems,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					hcEnvOpResLimitsCpuFieldName:      resourceField("CPU limit, e.g. `500m` or `1`."),
+					hcEnvOpResLimitsMemoryFieldName:   resourceField("Memory limit, e.g. `512Mi` or `1Gi`."),
+					hcEnvOpResRequestsCpuFieldName:    resourceField("CPU request, e.g. `100m`."),
+					hcEnvOpResRequestsMemoryFieldName: resourceField("Memory request, e.g. `256Mi`."),
+				},
+			},
+		},
+		hcEnvOpExtraYamlFieldName: extraYaml,
+	}
+}
+
+// suppressEquivalentYAMLDiff is a DiffSuppressFunc that ignores formatting and key order changes in YAML documents.
+func suppressEquivalentYAMLDiff(k, old, new string, d *schema.ResourceData) bool {
+	return yamlSemanticallyEqual(old, new)
+}
+
+// yamlSemanticallyEqual returns true if both YAML documents decode into the same data.
+func yamlSemanticallyEqual(a, b string) bool {
+	var aData, bData interface{}
+	if err := yaml.Unmarshal([]byte(a), &aData); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(b), &bData); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aData, bData)
+}
+
+// validateYAML is a SchemaValidateFunc that ensures the provided value is valid YAML.
+func validateYAML(v interface{}, k string) (ws []string, es []error) {
+	if err := yaml.Unmarshal([]byte(v.(string)), new(interface{})); err != nil {
+		es = append(es, fmt.Errorf("%q contains invalid YAML: %w", k, err))
+	}
+	return
+}
+
+// validateYAMLMap is a SchemaValidateFunc that ensures the provided value is a YAML map (or empty).
+func validateYAMLMap(v interface{}, k string) (ws []string, es []error) {
+	if _, err := parseYAMLMap(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q %w", k, err))
+	}
+	return
+}
+
+// normalizeYAMLStateFunc normalizes the YAML on save, which is good practice with DiffSuppressFunc.
+// This ensures the state file has a consistent format, even if user input varies.
+func normalizeYAMLStateFunc(v interface{}) string {
+	var data interface{}
+	if err := yaml.Unmarshal([]byte(v.(string)), &data); err != nil {
+		return v.(string) // On error, keep original
+	}
+	out, _ := yaml.Marshal(data)
+	return string(out)
+}
+
+// parseYAMLMap parses the YAML document into a map with string keys (as structpb expects).
+// An empty document results in an empty map.
+func parseYAMLMap(s string) (map[string]interface{}, error) {
+	var data interface{}
+	if err := yaml.Unmarshal([]byte(s), &data); err != nil {
+		return nil, fmt.Errorf("contains invalid YAML: %w", err)
+	}
+	if data == nil {
+		return map[string]interface{}{}, nil
+	}
+	m, ok := convertMapKeysToStrings(data).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a YAML map, got %T", data)
+	}
+	return m, nil
+}
+
+// hcEnvOperatorSettingsConfigured returns true if the typed operator_settings block is present in the configuration.
+func hcEnvOperatorSettingsConfigured(rawConfig cty.Value) bool {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().IsObjectType() {
+		return false
+	}
+	cfg := rawConfig.GetAttr(hcEnvConfigurationFieldName)
+	if cfg.IsNull() || !cfg.IsKnown() || cfg.LengthInt() == 0 {
+		return false
+	}
+	settings := cfg.Index(cty.NumberIntVal(0)).GetAttr(hcEnvCfgOperatorSettingsFieldName)
+	return !settings.IsNull() && settings.IsKnown() && settings.LengthInt() > 0
+}
+
+// expandHCEnvOperatorSettings builds the advanced operator settings from the typed operator_settings block.
+// The extra_yaml document is used as the base, the typed settings are merged on top of it.
+func expandHCEnvOperatorSettings(v []interface{}) (*structpb.Struct, error) {
+	if len(v) == 0 || v[0] == nil {
+		return nil, nil
+	}
+	m := v[0].(map[string]interface{})
+
+	settings := map[string]interface{}{}
+	if val, ok := m[hcEnvOpExtraYamlFieldName]; ok && val.(string) != "" {
+		extra, err := parseYAMLMap(val.(string))
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", hcEnvOpExtraYamlFieldName, err)
+		}
+		settings = extra
+	}
+	if val, ok := m[hcEnvOpFeatureFlagsFieldName]; ok {
+		if flags, ok := val.(map[string]interface{}); ok && len(flags) > 0 {
+			features := subMap(settings, hcEnvOpFeaturesKey)
+			for k, enabled := range flags {
+				features[k] = enabled
+			}
+		}
+	}
+	if val, ok := m[hcEnvOpReconcileConcurrencyFieldName]; ok && val.(int) > 0 {
+		settings[hcEnvOpReconcileConcurrencyKey] = val.(int)
+	}
+	if val, ok := m[hcEnvOpLogFormatFieldName]; ok && val.(string) != "" {
+		settings[hcEnvOpLogFormatKey] = val.(string)
+	}
+	if val, ok := m[hcEnvOpResourcesFieldName]; ok {
+		if list, ok := val.([]interface{}); ok && len(list) > 0 && list[0] != nil {
+			res := list[0].(map[string]interface{})
+			for field, path := range hcEnvOpResourceFields {
+				if q, ok := res[field].(string); ok && q != "" {
+					subMap(subMap(settings, hcEnvOpResourcesKey), path[0])[path[1]] = q
+				}
+			}
+		}
+	}
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	return structpb.NewStruct(settings)
+}
+
+// subMap returns the nested map stored under key, creating (or replacing a non-map value) if needed.
+func subMap(m map[string]interface{}, key string) map[string]interface{} {
+	if sub, ok := m[key].(map[string]interface{}); ok {
+		return sub
+	}
+	sub := map[string]interface{}{}
+	m[key] = sub
+	return sub
+}
+
+// flattenHCEnvOperatorSettings splits the advanced operator settings into the typed settings,
+// the remaining keys are returned in extra_yaml.
+func flattenHCEnvOperatorSettings(adv *structpb.Struct) []interface{} {
+	if adv == nil {
+		return []interface{}{}
+	}
+	rest := adv.AsMap()
+	if len(rest) == 0 {
+		return []interface{}{}
+	}
+
+	featureFlags := map[string]interface{}{}
+	if features, ok := rest[hcEnvOpFeaturesKey].(map[string]interface{}); ok && allBoolValues(features) {
+		for k, v := range features {
+			featureFlags[k] = v
+		}
+		delete(rest, hcEnvOpFeaturesKey)
+	}
+	reconcileConcurrency := 0
+	if n, ok := rest[hcEnvOpReconcileConcurrencyKey].(float64); ok && n == math.Trunc(n) {
+		reconcileConcurrency = int(n)
+		delete(rest, hcEnvOpReconcileConcurrencyKey)
+	}
+	logFormat := ""
+	if s, ok := rest[hcEnvOpLogFormatKey].(string); ok {
+		logFormat = s
+		delete(rest, hcEnvOpLogFormatKey)
+	}
+	resources := []interface{}{}
+	if res, ok := rest[hcEnvOpResourcesKey].(map[string]interface{}); ok {
+		resMap := map[string]interface{}{}
+		for field, path := range hcEnvOpResourceFields {
+			section, ok := res[path[0]].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if q, ok := quantityString(section[path[1]]); ok {
+				resMap[field] = q
+				delete(section, path[1])
+			}
+		}
+		for _, section := range []string{hcEnvOpLimitsKey, hcEnvOpRequestsKey} {
+			if s, ok := res[section].(map[string]interface{}); ok && len(s) == 0 {
+				delete(res, section)
+			}
+		}
+		if len(res) == 0 {
+			delete(rest, hcEnvOpResourcesKey)
+		}
+		if len(resMap) > 0 {
+			resources = []interface{}{resMap}
+		}
+	}
+	extraYaml := ""
+	if len(rest) > 0 {
+		if yamlBytes, err := yaml.Marshal(rest); err == nil {
+			extraYaml = string(yamlBytes)
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			hcEnvOpFeatureFlagsFieldName:         featureFlags,
+			hcEnvOpReconcileConcurrencyFieldName: reconcileConcurrency,
+			hcEnvOpLogFormatFieldName:            logFormat,
+			hcEnvOpResourcesFieldName:            resources,
+			hcEnvOpExtraYamlFieldName:            extraYaml,
+		},
+	}
+}
+
+// allBoolValues returns true if all values in the map are booleans.
+func allBoolValues(m map[string]interface{}) bool {
+	for _, v := range m {
+		if _, ok := v.(bool); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// quantityString returns the resource quantity as string, numeric quantities (e.g. cpu: 1) are formatted.
+func quantityString(v interface{}) (string, bool) {
+	switch q := v.(type) {
+	case string:
+		return q, true
+	case float64:
+		return strconv.FormatFloat(q, 'f', -1, 64), true
+	}
+	return "", false
+}
@@ -0,0 +1,171 @@
+package qdrant
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestExpandHCEnvOperatorSettings(t *testing.T) {
+	settings, err := expandHCEnvOperatorSettings([]interface{}{
+		map[string]interface{}{
+			hcEnvOpFeatureFlagsFieldName:         map[string]interface{}{"clusterManager": true},
+			hcEnvOpReconcileConcurrencyFieldName: 4,
+			hcEnvOpLogFormatFieldName:            "json",
+			hcEnvOpResourcesFieldName: []interface{}{
+				map[string]interface{}{
+					hcEnvOpResLimitsCpuFieldName:      "1",
+					hcEnvOpResLimitsMemoryFieldName:   "1Gi",
+					hcEnvOpResRequestsCpuFieldName:    "100m",
+					hcEnvOpResRequestsMemoryFieldName: "",
+				},
+			},
+			hcEnvOpExtraYamlFieldName: "logFormat: console\nfeatures:\n  autoscaler: false\nresources:\n  requests:\n    memory: 256Mi\nqdrant:\n  annotations:\n    team: search\n",
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, settings)
+
+	assert.Equal(t, map[string]interface{}{
+		// Typed settings take precedence over the extra YAML.
+		"logFormat":            "json",
+		"reconcileConcurrency": float64(4),
+		// Feature flags and resources are merged with the extra YAML.
+		"features": map[string]interface{}{"clusterManager": true, "autoscaler": false},
+		"resources": map[string]interface{}{
+			"limits":   map[string]interface{}{"cpu": "1", "memory": "1Gi"},
+			"requests": map[string]interface{}{"cpu": "100m", "memory": "256Mi"},
+		},
+		"qdrant": map[string]interface{}{"annotations": map[string]interface{}{"team": "search"}},
+	}, settings.AsMap())
+}
+
+func TestExpandHCEnvOperatorSettings_Deterministic(t *testing.T) {
+	block := []interface{}{
+		map[string]interface{}{
+			hcEnvOpFeatureFlagsFieldName: map[string]interface{}{"b": true, "a": false, "c": true},
+			hcEnvOpExtraYamlFieldName:    "z: 1\ny: 2\nx:\n  b: 1\n  a: 2\n",
+		},
+	}
+	first, err := expandHCEnvOperatorSettings(block)
+	require.NoError(t, err)
+	want, err := yaml.Marshal(first.AsMap())
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		got, err := expandHCEnvOperatorSettings(block)
+		require.NoError(t, err)
+		out, err := yaml.Marshal(got.AsMap())
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(out))
+	}
+}
+
+func TestExpandHCEnvOperatorSettings_Empty(t *testing.T) {
+	settings, err := expandHCEnvOperatorSettings(nil)
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+
+	settings, err = expandHCEnvOperatorSettings([]interface{}{map[string]interface{}{hcEnvOpExtraYamlFieldName: ""}})
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+}
+
+func TestExpandHCEnvOperatorSettings_ExtraYamlMustBeAMap(t *testing.T) {
+	_, err := expandHCEnvOperatorSettings([]interface{}{map[string]interface{}{hcEnvOpExtraYamlFieldName: "- a\n- b\n"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a YAML map")
+}
+
+func TestFlattenHCEnvOperatorSettings(t *testing.T) {
+	adv, err := structpb.NewStruct(map[string]interface{}{
+		"features":             map[string]interface{}{"clusterManager": true},
+		"reconcileConcurrency": 4,
+		"logFormat":            "json",
+		"resources": map[string]interface{}{
+			"limits":   map[string]interface{}{"cpu": 1, "memory": "1Gi"},
+			"requests": map[string]interface{}{"cpu": "100m", "ephemeral-storage": "1Gi"},
+		},
+		"qdrant": map[string]interface{}{"annotations": map[string]interface{}{"team": "search"}},
+	})
+	require.NoError(t, err)
+
+	got := flattenHCEnvOperatorSettings(adv)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			hcEnvOpFeatureFlagsFieldName:         map[string]interface{}{"clusterManager": true},
+			hcEnvOpReconcileConcurrencyFieldName: 4,
+			hcEnvOpLogFormatFieldName:            "json",
+			hcEnvOpResourcesFieldName: []interface{}{
+				map[string]interface{}{
+					hcEnvOpResLimitsCpuFieldName:    "1",
+					hcEnvOpResLimitsMemoryFieldName: "1Gi",
+					hcEnvOpResRequestsCpuFieldName:  "100m",
+				},
+			},
+			// Unknown keys (including unknown resources) are kept in the extra YAML.
+			hcEnvOpExtraYamlFieldName: "qdrant:\n  annotations:\n    team: search\nresources:\n  requests:\n    ephemeral-storage: 1Gi\n",
+		},
+	}, got)
+
+	t.Run("round trip results in the same settings", func(t *testing.T) {
+		expanded, err := expandHCEnvOperatorSettings(got)
+		require.NoError(t, err)
+		want := adv.AsMap()
+		want["resources"].(map[string]interface{})["limits"].(map[string]interface{})["cpu"] = "1"
+		assert.Equal(t, want, expanded.AsMap())
+	})
+	t.Run("non boolean features are kept in the extra YAML", func(t *testing.T) {
+		adv, err := structpb.NewStruct(map[string]interface{}{"features": map[string]interface{}{"mode": "fast"}})
+		require.NoError(t, err)
+		got := flattenHCEnvOperatorSettings(adv)
+		require.Len(t, got, 1)
+		block := got[0].(map[string]interface{})
+		assert.Empty(t, block[hcEnvOpFeatureFlagsFieldName])
+		assert.Equal(t, "features:\n  mode: fast\n", block[hcEnvOpExtraYamlFieldName])
+	})
+	t.Run("no settings results in an empty block list", func(t *testing.T) {
+		assert.Empty(t, flattenHCEnvOperatorSettings(nil))
+		assert.Empty(t, flattenHCEnvOperatorSettings(&structpb.Struct{}))
+	})
+}
+
+func TestYAMLSemanticallyEqual(t *testing.T) {
+	assert.True(t, yamlSemanticallyEqual("a: 1\nb:\n  c: true\n", "b: {c: true}\na: 1"))
+	assert.True(t, yamlSemanticallyEqual("", ""))
+	assert.False(t, yamlSemanticallyEqual("a: 1\n", "a: 2\n"))
+	assert.False(t, yamlSemanticallyEqual("a: [", "a: ["))
+	assert.True(t, suppressEquivalentYAMLDiff("", "x: 1\ny: 2\n", "y: 2\nx: 1\n", nil))
+}
+
+func TestValidateYAMLMap(t *testing.T) {
+	_, es := validateYAMLMap("a: 1\n", "extra_yaml")
+	assert.Empty(t, es)
+	_, es = validateYAMLMap("", "extra_yaml")
+	assert.Empty(t, es)
+	_, es = validateYAMLMap("- a\n", "extra_yaml")
+	assert.NotEmpty(t, es)
+	_, es = validateYAMLMap("a: [", "extra_yaml")
+	assert.NotEmpty(t, es)
+}
+
+func TestHCEnvOperatorSettingsConfigured(t *testing.T) {
+	settingsType := cty.List(cty.Object(map[string]cty.Type{hcEnvOpLogFormatFieldName: cty.String}))
+	rawConfig := func(settings cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			hcEnvConfigurationFieldName: cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{hcEnvCfgOperatorSettingsFieldName: settings}),
+			}),
+		})
+	}
+
+	assert.True(t, hcEnvOperatorSettingsConfigured(rawConfig(cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{hcEnvOpLogFormatFieldName: cty.StringVal("json")}),
+	}))))
+	assert.False(t, hcEnvOperatorSettingsConfigured(rawConfig(cty.ListValEmpty(settingsType.ElementType()))))
+	assert.False(t, hcEnvOperatorSettingsConfigured(rawConfig(cty.NullVal(settingsType))))
+	assert.False(t, hcEnvOperatorSettingsConfigured(cty.NullVal(cty.DynamicPseudoType)))
+}
@@ -0,0 +1,54 @@
+package qdrant
+
+import (
+	"fmt"
+)
+
+// hcEnvBootstrapAffectingFields contains the configuration fields which are rendered into the bootstrap commands
+// (the registries, proxies and certificates used to install the components, and their settings and scheduling).
+var hcEnvBootstrapAffectingFields = []string{
+	hcEnvCfgHttpProxyUrlFieldName,
+	hcEnvCfgHttpsProxyUrlFieldName,
+	hcEnvCfgNoProxyConfigsFieldName,
+	hcEnvCfgContainerRegistryUrlFieldName,
+	hcEnvCfgChartRepositoryUrlFieldName,
+	hcEnvCfgRegistrySecretNameFieldName,
+	hcEnvCfgCaCertificatesFieldName,
+	hcEnvCfgLogLevelFieldName,
+	hcEnvCfgAdvancedOperatorSettingsFieldName,
+	hcEnvCfgOperatorSettingsFieldName,
+	hcEnvCfgNodeSelectorFieldName,
+	hcEnvCfgTolerationsFieldName,
+	hcEnvCfgControlPlaneLabelsFieldName,
+}
+
+// hcEnvChangeDetector is implemented by both schema.ResourceData and schema.ResourceDiff.
+type hcEnvChangeDetector interface {
+	Id() string
+	Get(key string) interface{}
+	HasChange(key string) bool
+}
+
+// changedHCEnvBootstrapFields returns the (relative) keys of the changed configuration fields which affect the bootstrap commands.
+func changedHCEnvBootstrapFields(d hcEnvChangeDetector) []string {
+	var changed []string
+	for _, k := range hcEnvBootstrapAffectingFields {
+		if d.HasChange(fmt.Sprintf("%s.0.%s", hcEnvConfigurationFieldName, k)) {
+			changed = append(changed, k)
+		}
+	}
+	return changed
+}
+
+// hcEnvBootstrapRegenerationRequired returns true if the bootstrap commands of an existing environment should be
+// regenerated, because regenerate_on_change is set and a configuration field affecting the commands changed.
+// The commands are never regenerated if generation is disabled (bootstrap_commands_version is 0 or -1).
+func hcEnvBootstrapRegenerationRequired(d hcEnvChangeDetector) bool {
+	if d.Id() == "" || !d.Get(hcEnvRegenerateOnChangeFieldName).(bool) {
+		return false
+	}
+	if version, _ := d.Get(hcEnvBootstrapCommandsVersionFieldName).(int); version <= 0 {
+		return false
+	}
+	return len(changedHCEnvBootstrapFields(d)) > 0
+}
@@ -0,0 +1,85 @@
+package qdrant
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeHCEnvChangeDetector implements hcEnvChangeDetector for testing.
+type fakeHCEnvChangeDetector struct {
+	id      string
+	values  map[string]interface{}
+	changed map[string]bool
+}
+
+func (f fakeHCEnvChangeDetector) Id() string                 { return f.id }
+func (f fakeHCEnvChangeDetector) Get(key string) interface{} { return f.values[key] }
+func (f fakeHCEnvChangeDetector) HasChange(key string) bool  { return f.changed[key] }
+
+func TestHCEnvBootstrapRegenerationRequired(t *testing.T) {
+	proxyKey := "configuration.0." + hcEnvCfgHttpProxyUrlFieldName
+	base := func() fakeHCEnvChangeDetector {
+		return fakeHCEnvChangeDetector{
+			id: "env-1",
+			values: map[string]interface{}{
+				hcEnvRegenerateOnChangeFieldName:       true,
+				hcEnvBootstrapCommandsVersionFieldName: 1,
+			},
+			changed: map[string]bool{proxyKey: true},
+		}
+	}
+
+	t.Run("a bootstrap affecting field changed", func(t *testing.T) {
+		assert.True(t, hcEnvBootstrapRegenerationRequired(base()))
+	})
+	t.Run("regenerate_on_change not set", func(t *testing.T) {
+		d := base()
+		d.values[hcEnvRegenerateOnChangeFieldName] = false
+		assert.False(t, hcEnvBootstrapRegenerationRequired(d))
+	})
+	t.Run("generation disabled by the version", func(t *testing.T) {
+		for _, v := range []int{0, -1} {
+			d := base()
+			d.values[hcEnvBootstrapCommandsVersionFieldName] = v
+			assert.False(t, hcEnvBootstrapRegenerationRequired(d), "version %d", v)
+		}
+	})
+	t.Run("new environment", func(t *testing.T) {
+		d := base()
+		d.id = ""
+		assert.False(t, hcEnvBootstrapRegenerationRequired(d))
+	})
+	t.Run("only fields which don't affect the commands changed", func(t *testing.T) {
+		d := base()
+		d.changed = map[string]bool{
+			"configuration.0." + hcEnvCfgDatabaseStorageClassFieldName: true,
+			hcEnvNameFieldName: true,
+		}
+		assert.False(t, hcEnvBootstrapRegenerationRequired(d))
+	})
+}
+
+func TestChangedHCEnvBootstrapFields(t *testing.T) {
+	d := fakeHCEnvChangeDetector{changed: map[string]bool{
+		"configuration.0." + hcEnvCfgCaCertificatesFieldName:       true,
+		"configuration.0." + hcEnvCfgContainerRegistryUrlFieldName: true,
+		"configuration.0." + hcEnvCfgSnapshotStorageClassFieldName: true,
+	}}
+	assert.Equal(t, []string{hcEnvCfgContainerRegistryUrlFieldName, hcEnvCfgCaCertificatesFieldName}, changedHCEnvBootstrapFields(d))
+}
+
+func TestHCEnvBootstrapRegenerationRequired_ResourceData(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(false), map[string]interface{}{
+		hcEnvNameFieldName:                     "env",
+		hcEnvRegenerateOnChangeFieldName:       true,
+		hcEnvBootstrapCommandsVersionFieldName: 2,
+		hcEnvConfigurationFieldName: []interface{}{map[string]interface{}{
+			hcEnvCfgNamespaceFieldName:            "qdrant-hc",
+			hcEnvCfgContainerRegistryUrlFieldName: "registry.example.com",
+		}},
+	})
+	d.SetId("env-1")
+	assert.True(t, hcEnvBootstrapRegenerationRequired(d))
+}
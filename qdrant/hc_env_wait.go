@@ -0,0 +1,157 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+const (
+	hcEnvReadyPollInterval = 15 * time.Second
+	hcEnvReadyTimeout      = 30 * time.Minute
+
+	hcEnvWaitPending = "waiting"
+	hcEnvWaitReady   = "ready"
+)
+
+// isHCEnvReady returns true if the hybrid cloud environment is ready and allows the creation of clusters.
+func isHCEnvReady(env *qch.HybridCloudEnvironment) bool {
+	st := env.GetStatus()
+	return st.GetPhase() == qch.HybridCloudEnvironmentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_READY &&
+		st.GetClusterCreationReadiness() == qch.QdrantClusterCreationStatus_QDRANT_CLUSTER_CREATION_STATUS_READY
+}
+
+// describeHCEnvNotReady returns a human readable description of why the hybrid cloud environment isn't ready,
+// including the components which aren't ready (sorted by name).
+func describeHCEnvNotReady(env *qch.HybridCloudEnvironment) string {
+	st := env.GetStatus()
+	if st == nil {
+		return "no status reported yet, the bootstrap commands may not have been applied"
+	}
+	parts := []string{
+		fmt.Sprintf("phase=%s", st.GetPhase().String()),
+		fmt.Sprintf("cluster_creation_readiness=%s", st.GetClusterCreationReadiness().String()),
+	}
+	if msg := strings.TrimSpace(st.GetMessage()); msg != "" {
+		parts = append(parts, fmt.Sprintf("message=%q", msg))
+	}
+	var failing []string
+	for _, c := range st.GetComponentStatuses() {
+		if c.GetPhase() == qch.HybridCloudEnvironmentComponentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_READY {
+			continue
+		}
+		item := fmt.Sprintf("%s (%s)", c.GetName(), c.GetPhase().String())
+		if msg := strings.TrimSpace(c.GetMessage()); msg != "" {
+			item += ": " + msg
+		}
+		failing = append(failing, item)
+	}
+	result := strings.Join(parts, ", ")
+	if len(failing) > 0 {
+		sort.Strings(failing)
+		result += "; components not ready: " + strings.Join(failing, "; ")
+	}
+	return result
+}
+
+// hcEnvReadyRefreshFunc returns a StateRefreshFunc that polls GetHybridCloudEnvironment until the environment is ready.
+// The last fetched environment is stored in last, so the reason can be reported if the wait times out.
+func hcEnvReadyRefreshFunc(
+	client qch.HybridCloudServiceClient,
+	ctx context.Context,
+	accountID, envID string,
+	last **qch.HybridCloudEnvironment,
+) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var trailer metadata.MD
+		resp, err := client.GetHybridCloudEnvironment(ctx, &qch.GetHybridCloudEnvironmentRequest{
+			AccountId:                accountID,
+			HybridCloudEnvironmentId: envID,
+		}, grpc.Trailer(&trailer))
+		if err != nil {
+			return nil, "", fmt.Errorf("error getting hybrid cloud environment%s: %w", getRequestID(trailer), err)
+		}
+		env := resp.GetHybridCloudEnvironment()
+		*last = env
+		if isHCEnvReady(env) {
+			return env, hcEnvWaitReady, nil
+		}
+		return env, hcEnvWaitPending, nil
+	}
+}
+
+// waitForHCEnvReady waits until the hybrid cloud environment is ready (its agent is connected and clusters can be created).
+// If the environment doesn't become ready in time, the error contains the status and the components which aren't ready.
+func waitForHCEnvReady(
+	ctx context.Context,
+	client qch.HybridCloudServiceClient,
+	clientCtx context.Context,
+	accountID, envID string,
+	timeout time.Duration,
+) (*qch.HybridCloudEnvironment, error) {
+	var last *qch.HybridCloudEnvironment
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{hcEnvWaitPending},
+		Target:       []string{hcEnvWaitReady},
+		Refresh:      hcEnvReadyRefreshFunc(client, clientCtx, accountID, envID, &last),
+		Timeout:      timeout,
+		PollInterval: hcEnvReadyPollInterval,
+	}
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		if last != nil {
+			return nil, fmt.Errorf("hybrid cloud environment %s is not ready (%s): %w", envID, describeHCEnvNotReady(last), err)
+		}
+		return nil, err
+	}
+	return result.(*qch.HybridCloudEnvironment), nil
+}
+
+// hcEnvReadyDiagnostics converts the result of waiting for a hybrid cloud environment into diagnostics.
+// A timeout is reported as an error (also on create), with the components which aren't ready (see describeHCEnvNotReady).
+func hcEnvReadyDiagnostics(err error, errorPrefix string) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var timeoutErr *retry.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s: hybrid cloud environment not ready", errorPrefix),
+			Detail:   fmt.Sprintf("%s. Make sure the bootstrap commands have been applied to the Kubernetes cluster, or increase the timeout.", err),
+		}}
+	}
+	return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+}
+
+// planHCEnvWaitForReady marks the status of an existing hybrid cloud environment as changing if wait_for_ready is set,
+// the environment wasn't ready when last read and the configuration changes (including wait_for_ready itself),
+// so the update waits for it (before dependent clusters are created).
+// Without a configuration change the plan stays empty, even if the environment isn't ready.
+func planHCEnvWaitForReady(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.Get(hcEnvWaitForReadyFieldName).(bool) {
+		return nil
+	}
+	if len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	statusPrefix := hcEnvStatusFieldName + ".0."
+	phase, _ := d.Get(statusPrefix + hcEnvStatusPhaseFieldName).(string)
+	readiness, _ := d.Get(statusPrefix + hcEnvStatusClusterCreationReadinessFieldName).(string)
+	if phase == qch.HybridCloudEnvironmentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_READY.String() &&
+		readiness == qch.QdrantClusterCreationStatus_QDRANT_CLUSTER_CREATION_STATUS_READY.String() {
+		return nil
+	}
+	return d.SetNewComputed(hcEnvStatusFieldName)
+}
@@ -0,0 +1,206 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+type mockHybridCloudServiceClient struct {
+	qch.HybridCloudServiceClient
+	envs      []*qch.HybridCloudEnvironment
+	err       error
+	callCount int
+}
+
+func (m *mockHybridCloudServiceClient) GetHybridCloudEnvironment(_ context.Context, _ *qch.GetHybridCloudEnvironmentRequest, _ ...grpc.CallOption) (*qch.GetHybridCloudEnvironmentResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	idx := m.callCount
+	m.callCount++
+	if idx >= len(m.envs) {
+		idx = len(m.envs) - 1
+	}
+	return &qch.GetHybridCloudEnvironmentResponse{HybridCloudEnvironment: m.envs[idx]}, nil
+}
+
+func newReadyHCEnv() *qch.HybridCloudEnvironment {
+	return &qch.HybridCloudEnvironment{
+		Id: "env-1",
+		Status: &qch.HybridCloudEnvironmentStatus{
+			Phase:                    qch.HybridCloudEnvironmentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_READY,
+			ClusterCreationReadiness: qch.QdrantClusterCreationStatus_QDRANT_CLUSTER_CREATION_STATUS_READY,
+		},
+	}
+}
+
+func newNotReadyHCEnv() *qch.HybridCloudEnvironment {
+	return &qch.HybridCloudEnvironment{
+		Id: "env-1",
+		Status: &qch.HybridCloudEnvironmentStatus{
+			Phase:                    qch.HybridCloudEnvironmentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_NOT_READY,
+			ClusterCreationReadiness: qch.QdrantClusterCreationStatus_QDRANT_CLUSTER_CREATION_STATUS_NOT_READY,
+			Message:                  "operator not ready",
+			ComponentStatuses: []*qch.HybridCloudEnvironmentComponentStatus{
+				{Name: "qdrant-operator", Phase: qch.HybridCloudEnvironmentComponentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_FAILED, Message: "ImagePullBackOff"},
+				{Name: "qdrant-cloud-agent", Phase: qch.HybridCloudEnvironmentComponentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_READY},
+				{Name: "prometheus", Phase: qch.HybridCloudEnvironmentComponentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_NOT_READY},
+			},
+		},
+	}
+}
+
+func TestIsHCEnvReady(t *testing.T) {
+	assert.True(t, isHCEnvReady(newReadyHCEnv()))
+	assert.False(t, isHCEnvReady(newNotReadyHCEnv()))
+	assert.False(t, isHCEnvReady(&qch.HybridCloudEnvironment{}))
+
+	// The environment can be ready, while clusters can't be created yet.
+	env := newReadyHCEnv()
+	env.Status.ClusterCreationReadiness = qch.QdrantClusterCreationStatus_QDRANT_CLUSTER_CREATION_STATUS_NOT_READY
+	assert.False(t, isHCEnvReady(env))
+}
+
+func TestDescribeHCEnvNotReady(t *testing.T) {
+	assert.Equal(t,
+		`phase=HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_NOT_READY, cluster_creation_readiness=QDRANT_CLUSTER_CREATION_STATUS_NOT_READY, message="operator not ready"; `+
+			`components not ready: prometheus (HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_NOT_READY); qdrant-operator (HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_FAILED): ImagePullBackOff`,
+		describeHCEnvNotReady(newNotReadyHCEnv()))
+	assert.Contains(t, describeHCEnvNotReady(&qch.HybridCloudEnvironment{}), "no status reported yet")
+}
+
+func TestHCEnvReadyRefreshFunc(t *testing.T) {
+	mock := &mockHybridCloudServiceClient{envs: []*qch.HybridCloudEnvironment{newNotReadyHCEnv(), newReadyHCEnv()}}
+	var last *qch.HybridCloudEnvironment
+	refresh := hcEnvReadyRefreshFunc(mock, context.Background(), "acc-1", "env-1", &last)
+
+	_, state, err := refresh()
+	require.NoError(t, err)
+	assert.Equal(t, hcEnvWaitPending, state)
+	assert.False(t, isHCEnvReady(last))
+
+	result, state, err := refresh()
+	require.NoError(t, err)
+	assert.Equal(t, hcEnvWaitReady, state)
+	assert.Equal(t, "env-1", result.(*qch.HybridCloudEnvironment).GetId())
+
+	mock.err = errors.New("unavailable")
+	_, _, err = refresh()
+	assert.ErrorContains(t, err, "unavailable")
+}
+
+func TestWaitForHCEnvReady(t *testing.T) {
+	mock := &mockHybridCloudServiceClient{envs: []*qch.HybridCloudEnvironment{newReadyHCEnv()}}
+	env, err := waitForHCEnvReady(context.Background(), mock, context.Background(), "acc-1", "env-1", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "env-1", env.GetId())
+
+	mock = &mockHybridCloudServiceClient{envs: []*qch.HybridCloudEnvironment{newNotReadyHCEnv()}}
+	_, err = waitForHCEnvReady(context.Background(), mock, context.Background(), "acc-1", "env-1", 100*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hybrid cloud environment env-1 is not ready")
+	assert.Contains(t, err.Error(), "qdrant-operator (HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_FAILED): ImagePullBackOff")
+}
+
+func TestHCEnvReadyDiagnostics(t *testing.T) {
+	assert.Empty(t, hcEnvReadyDiagnostics(nil, "error creating hybrid cloud environment"))
+
+	mock := &mockHybridCloudServiceClient{envs: []*qch.HybridCloudEnvironment{newNotReadyHCEnv()}}
+	_, timeoutErr := waitForHCEnvReady(context.Background(), mock, context.Background(), "acc-1", "env-1", 100*time.Millisecond)
+	require.Error(t, timeoutErr)
+
+	// A timeout is an error (also on create), listing the components which aren't ready.
+	diags := hcEnvReadyDiagnostics(timeoutErr, "error creating hybrid cloud environment")
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "error creating hybrid cloud environment: hybrid cloud environment not ready", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "qdrant-operator (HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_FAILED): ImagePullBackOff")
+	assert.Contains(t, diags[0].Detail, "prometheus (HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_NOT_READY)")
+	assert.NotContains(t, diags[0].Detail, "qdrant-cloud-agent")
+
+	// Other errors are reported as they are
+	diags = hcEnvReadyDiagnostics(errors.New("unavailable"), "error creating hybrid cloud environment")
+	require.True(t, diags.HasError())
+	assert.Equal(t, "error creating hybrid cloud environment: unavailable", diags[0].Summary)
+}
+
+func TestPlanHCEnvWaitForReady(t *testing.T) {
+	r := &schema.Resource{
+		Schema:        accountsHybridCloudEnvironmentSchema(false),
+		CustomizeDiff: planHCEnvWaitForReady,
+	}
+	newState := func(waitForReady string, phase qch.HybridCloudEnvironmentStatusPhase) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "env-1",
+			Attributes: map[string]string{
+				"id":                                   "env-1",
+				hcEnvNameFieldName:                     "env",
+				hcEnvWaitForReadyFieldName:             waitForReady,
+				hcEnvBootstrapCommandsFieldName + ".#": "0",
+				hcEnvBootstrapFieldName + ".#":         "0",
+				hcEnvCaCertificatesFingerprintsFieldName + ".#":                             "0",
+				hcEnvStatusFieldName + ".#":                                                 "1",
+				hcEnvStatusFieldName + ".0." + hcEnvStatusPhaseFieldName:                    phase.String(),
+				hcEnvStatusFieldName + ".0." + hcEnvStatusClusterCreationReadinessFieldName: qch.QdrantClusterCreationStatus_QDRANT_CLUSTER_CREATION_STATUS_NOT_READY.String(),
+			},
+		}
+	}
+	notReady := qch.HybridCloudEnvironmentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_STATUS_PHASE_NOT_READY
+
+	for name, tc := range map[string]struct {
+		state      *terraform.InstanceState
+		config     map[string]interface{}
+		wantStatus bool
+	}{
+		"not ready without changes": {
+			state:  newState("true", notReady),
+			config: map[string]interface{}{hcEnvNameFieldName: "env", hcEnvWaitForReadyFieldName: true},
+		},
+		"not ready with a configuration change": {
+			state:      newState("true", notReady),
+			config:     map[string]interface{}{hcEnvNameFieldName: "env-2", hcEnvWaitForReadyFieldName: true},
+			wantStatus: true,
+		},
+		"not ready when wait_for_ready is enabled": {
+			state:      newState("false", notReady),
+			config:     map[string]interface{}{hcEnvNameFieldName: "env", hcEnvWaitForReadyFieldName: true},
+			wantStatus: true,
+		},
+		"not ready without wait_for_ready": {
+			state:  newState("false", notReady),
+			config: map[string]interface{}{hcEnvNameFieldName: "env-2"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diff, err := r.Diff(context.Background(), tc.state, terraform.NewResourceConfigRaw(tc.config), nil)
+			require.NoError(t, err)
+			var statusChanging bool
+			if diff != nil {
+				_, statusChanging = diff.Attributes[hcEnvStatusFieldName+".#"]
+			}
+			assert.Equal(t, tc.wantStatus, statusChanging)
+		})
+	}
+}
+
+func TestHCEnvWaitForReadySchema(t *testing.T) {
+	assert.Contains(t, resourceAccountsHybridCloudEnvironment().Schema, hcEnvWaitForReadyFieldName)
+	s := accountsHybridCloudEnvironmentSchema(false)
+	assert.True(t, s[hcEnvWaitForReadyFieldName].Optional)
+	assert.Equal(t, schema.TypeBool, s[hcEnvWaitForReadyFieldName].Type)
+	timeouts := resourceAccountsHybridCloudEnvironment().Timeouts
+	require.NotNil(t, timeouts)
+	assert.Equal(t, hcEnvReadyTimeout, *timeouts.Create)
+	assert.Equal(t, hcEnvReadyTimeout, *timeouts.Update)
+}
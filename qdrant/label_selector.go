@@ -0,0 +1,161 @@
+package qdrant
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+// Operators of label selector requirements (Kubernetes style).
+const (
+	labelSelectorOpEquals       = "="
+	labelSelectorOpNotEquals    = "!="
+	labelSelectorOpIn           = "in"
+	labelSelectorOpNotIn        = "notin"
+	labelSelectorOpExists       = "exists"
+	labelSelectorOpDoesNotExist = "!"
+)
+
+var (
+	// labelSelectorKeyRegex matches a valid label key.
+	labelSelectorKeyRegex = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_./-]*[A-Za-z0-9])?$`)
+	// labelSelectorSetRegex matches a set based requirement, e.g. "team in (a, b)".
+	labelSelectorSetRegex = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\(([^()]*)\)$`)
+)
+
+// labelRequirement is a single requirement of a label selector.
+type labelRequirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// parseLabelSelector parses a (Kubernetes style) label selector, e.g. "env=prod,team in (a,b),!deprecated".
+// Supported requirements are "key=value", "key==value", "key!=value", "key in (v1,v2)", "key notin (v1,v2)",
+// "key" (exists) and "!key" (does not exist). All requirements should match.
+func parseLabelSelector(selector string) ([]labelRequirement, error) {
+	var result []labelRequirement
+	for _, part := range splitLabelSelector(selector) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		req, err := parseLabelRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, req)
+	}
+	return result, nil
+}
+
+// splitLabelSelector splits the selector on the commas which are not part of a set (between parentheses).
+func splitLabelSelector(selector string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, selector[start:])
+}
+
+// parseLabelRequirement parses a single requirement of a label selector.
+func parseLabelRequirement(s string) (labelRequirement, error) {
+	var req labelRequirement
+	switch {
+	case labelSelectorSetRegex.MatchString(s):
+		m := labelSelectorSetRegex.FindStringSubmatch(s)
+		req = labelRequirement{Key: m[1], Operator: m[2]}
+		for _, v := range strings.Split(m[3], ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				req.Values = append(req.Values, v)
+			}
+		}
+		if len(req.Values) == 0 {
+			return req, fmt.Errorf("invalid label selector requirement %q: the set of values should not be empty", s)
+		}
+	case strings.HasPrefix(s, "!") && !strings.Contains(s, "="):
+		req = labelRequirement{Key: strings.TrimSpace(s[1:]), Operator: labelSelectorOpDoesNotExist}
+	case strings.Contains(s, "!="):
+		key, value, _ := strings.Cut(s, "!=")
+		req = labelRequirement{Key: strings.TrimSpace(key), Operator: labelSelectorOpNotEquals, Values: []string{strings.TrimSpace(value)}}
+	case strings.Contains(s, "="):
+		key, value, _ := strings.Cut(s, "=")
+		value = strings.TrimPrefix(value, "=")
+		req = labelRequirement{Key: strings.TrimSpace(key), Operator: labelSelectorOpEquals, Values: []string{strings.TrimSpace(value)}}
+	default:
+		req = labelRequirement{Key: s, Operator: labelSelectorOpExists}
+	}
+	if !labelSelectorKeyRegex.MatchString(req.Key) {
+		return req, fmt.Errorf("invalid label selector requirement %q: invalid key %q", s, req.Key)
+	}
+	return req, nil
+}
+
+// matches returns true if the provided labels meet the requirement.
+func (r labelRequirement) matches(labels map[string]string) bool {
+	value, exists := labels[r.Key]
+	switch r.Operator {
+	case labelSelectorOpExists:
+		return exists
+	case labelSelectorOpDoesNotExist:
+		return !exists
+	case labelSelectorOpEquals:
+		return exists && value == r.Values[0]
+	case labelSelectorOpNotEquals:
+		return !exists || value != r.Values[0]
+	case labelSelectorOpIn:
+		return exists && containsString(r.Values, value)
+	case labelSelectorOpNotIn:
+		return !exists || !containsString(r.Values, value)
+	}
+	return false
+}
+
+// matchLabelSelector returns true if the provided labels meet all requirements.
+func matchLabelSelector(requirements []labelRequirement, labels []*commonv1.KeyValue) bool {
+	values := make(map[string]string, len(labels))
+	for _, kv := range labels {
+		values[kv.GetKey()] = kv.GetValue()
+	}
+	for _, r := range requirements {
+		if !r.matches(values) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateLabelSelector is a SchemaValidateFunc that ensures the provided value is a valid label selector.
+func validateLabelSelector(v interface{}, k string) (ws []string, es []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a string", k)}
+	}
+	if _, err := parseLabelSelector(s); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// containsString returns true if the slice contains the provided value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package qdrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	reqs, err := parseLabelSelector("env=prod, tier==gold,team in (a, b),owner notin (x),zone!=eu,critical,!deprecated")
+	require.NoError(t, err)
+	assert.Equal(t, []labelRequirement{
+		{Key: "env", Operator: labelSelectorOpEquals, Values: []string{"prod"}},
+		{Key: "tier", Operator: labelSelectorOpEquals, Values: []string{"gold"}},
+		{Key: "team", Operator: labelSelectorOpIn, Values: []string{"a", "b"}},
+		{Key: "owner", Operator: labelSelectorOpNotIn, Values: []string{"x"}},
+		{Key: "zone", Operator: labelSelectorOpNotEquals, Values: []string{"eu"}},
+		{Key: "critical", Operator: labelSelectorOpExists},
+		{Key: "deprecated", Operator: labelSelectorOpDoesNotExist},
+	}, reqs)
+
+	reqs, err = parseLabelSelector("")
+	require.NoError(t, err)
+	assert.Empty(t, reqs)
+
+	for _, invalid := range []string{"team in ()", "=prod", "env prod", "!", "team in (a,b"} {
+		_, err := parseLabelSelector(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestMatchLabelSelector(t *testing.T) {
+	labels := []*commonv1.KeyValue{{Key: "env", Value: "prod"}, {Key: "team", Value: "search"}}
+	for selector, expected := range map[string]bool{
+		"":                            true,
+		"env=prod":                    true,
+		"env=dev":                     false,
+		"env!=dev":                    true,
+		"owner!=me":                   true,
+		"team in (search, recommend)": true,
+		"team in (recommend)":         false,
+		"team notin (recommend)":      true,
+		"owner notin (me)":            true,
+		"owner in (me)":               false,
+		"env":                         true,
+		"owner":                       false,
+		"!owner":                      true,
+		"!env":                        false,
+		"env=prod,team in (a,search)": true,
+		"env=prod,team in (a,b)":      false,
+	} {
+		reqs, err := parseLabelSelector(selector)
+		require.NoError(t, err, selector)
+		assert.Equal(t, expected, matchLabelSelector(reqs, labels), selector)
+	}
+}
+
+func TestValidateLabelSelector(t *testing.T) {
+	_, es := validateLabelSelector("env=prod,team in (a,b)", "label_selector")
+	assert.Empty(t, es)
+	_, es = validateLabelSelector("team in ()", "label_selector")
+	assert.Len(t, es, 1)
+	_, es = validateLabelSelector(1, "label_selector")
+	assert.Len(t, es, 1)
+}
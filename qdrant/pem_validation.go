@@ -0,0 +1,195 @@
+package qdrant
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// certificateExpiryWarningPeriod is the period before the expiry of a certificate in which a warning is reported.
+const certificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// parsePEMCertificates parses all PEM encoded certificates in the provided string.
+// Returns an error if the string contains no PEM block, a block which isn't a certificate, an invalid certificate or trailing data.
+func parsePEMCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("contains a %q PEM block, only certificates are allowed", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("contains an invalid certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("contains no PEM encoded certificate")
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, errors.New("contains data which isn't PEM encoded")
+	}
+	return certs, nil
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint of the certificate as colon separated upper case hex,
+// as printed by `openssl x509 -noout -fingerprint -sha256`.
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	parts := make([]string, 0, len(sum))
+	for _, b := range sum {
+		parts = append(parts, fmt.Sprintf("%02X", b))
+	}
+	return strings.Join(parts, ":")
+}
+
+// formatCertificateTime formats a validity bound of a certificate as RFC3339 (UTC).
+func formatCertificateTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// describeCertificate returns a human readable reference to the certificate, e.g. `certificate 1 ("CN=My CA")`.
+func describeCertificate(idx int, cert *x509.Certificate) string {
+	return fmt.Sprintf("certificate %d (%q)", idx+1, cert.Subject.String())
+}
+
+// checkCertificates checks the validity period of the provided certificates at the provided time,
+// and if requireCA is set, that all of them are CA certificates.
+// Returns a warning per certificate which is expired, not valid yet or expiring within certificateExpiryWarningPeriod,
+// and an error per certificate which isn't a CA certificate. The validity period only results in warnings,
+// as it depends on the time of the plan: an expiring certificate must not break every plan of an unchanged configuration.
+func checkCertificates(certs []*x509.Certificate, requireCA bool, now time.Time) (ws []string, es []error) {
+	for i, cert := range certs {
+		switch {
+		case now.After(cert.NotAfter):
+			ws = append(ws, fmt.Sprintf("%s expired at %s", describeCertificate(i, cert), formatCertificateTime(cert.NotAfter)))
+		case now.Before(cert.NotBefore):
+			ws = append(ws, fmt.Sprintf("%s isn't valid before %s", describeCertificate(i, cert), formatCertificateTime(cert.NotBefore)))
+		case cert.NotAfter.Sub(now) < certificateExpiryWarningPeriod:
+			ws = append(ws, fmt.Sprintf("%s expires at %s (within %d days)", describeCertificate(i, cert),
+				formatCertificateTime(cert.NotAfter), int(certificateExpiryWarningPeriod.Hours()/24)))
+		}
+		if requireCA && !cert.IsCA {
+			es = append(es, fmt.Errorf("%s isn't a CA certificate (basic constraints CA flag not set)", describeCertificate(i, cert)))
+		}
+	}
+	return ws, es
+}
+
+// validateCACertificatesPEM is a SchemaValidateFunc that ensures the provided value contains valid PEM encoded CA certificates (or is empty).
+// Warns if a certificate is expired, not valid yet or expires within certificateExpiryWarningPeriod.
+func validateCACertificatesPEM(v interface{}, k string) (ws []string, es []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a string", k)}
+	}
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	return validateCertificatesPEM(s, k, true, time.Now())
+}
+
+// validateCertificatePEM is a SchemaValidateFunc that ensures the provided value contains a valid PEM encoded certificate
+// (optionally followed by its chain). Warns if a certificate is expired, not valid yet or expires within certificateExpiryWarningPeriod.
+func validateCertificatePEM(v interface{}, k string) (ws []string, es []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a string", k)}
+	}
+	return validateCertificatesPEM(s, k, false, time.Now())
+}
+
+// validateCertificatesPEM parses and checks the provided PEM encoded certificates at the provided time.
+func validateCertificatesPEM(s, k string, requireCA bool, now time.Time) (ws []string, es []error) {
+	certs, err := parsePEMCertificates(s)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s %w", k, err)}
+	}
+	ws, es = checkCertificates(certs, requireCA, now)
+	for i := range ws {
+		ws[i] = fmt.Sprintf("%s: %s", k, ws[i])
+	}
+	for i := range es {
+		es[i] = fmt.Errorf("%s: %w", k, es[i])
+	}
+	return ws, es
+}
+
+// validatePrivateKeyPEM is a SchemaValidateFunc that ensures the provided value contains a single PEM encoded
+// private key (PKCS #1, PKCS #8 or SEC 1 EC).
+func validatePrivateKeyPEM(v interface{}, k string) (ws []string, es []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a string", k)}
+	}
+	if err := parsePEMPrivateKey(s); err != nil {
+		return nil, []error{fmt.Errorf("%s %w", k, err)}
+	}
+	return nil, nil
+}
+
+// parsePEMPrivateKey ensures the provided string contains a single PEM encoded private key.
+func parsePEMPrivateKey(data string) error {
+	block, rest := pem.Decode([]byte(data))
+	if block == nil {
+		return errors.New("contains no PEM encoded private key")
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return errors.New("must contain a single PEM encoded private key")
+	}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		_, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		_, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		_, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return fmt.Errorf("contains a %q PEM block, expected a private key", block.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("contains an invalid private key: %w", err)
+	}
+	return nil
+}
+
+// validateCertificateKeyPair ensures the private key matches the (first) certificate.
+func validateCertificateKeyPair(certPEM, keyPEM string) error {
+	if _, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM)); err != nil {
+		return fmt.Errorf("the private key doesn't match the certificate: %w", err)
+	}
+	return nil
+}
+
+// certificatesInfo returns the earliest expiry (RFC3339, empty if none) and the SHA-256 fingerprints
+// of the PEM encoded certificates in the provided string. Invalid PEM results in empty values.
+func certificatesInfo(data string) (string, []string) {
+	fingerprints := []string{}
+	if strings.TrimSpace(data) == "" {
+		return "", fingerprints
+	}
+	certs, err := parsePEMCertificates(data)
+	if err != nil {
+		return "", fingerprints
+	}
+	var notAfter time.Time
+	for _, cert := range certs {
+		fingerprints = append(fingerprints, certificateFingerprint(cert))
+		if notAfter.IsZero() || cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	return formatCertificateTime(notAfter), fingerprints
+}
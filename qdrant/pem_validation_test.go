@@ -0,0 +1,161 @@
+package qdrant
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificate returns a self-signed PEM encoded certificate and its PEM encoded (PKCS #8) private key.
+func newTestCertificate(t *testing.T, commonName string, isCA bool, notBefore, notAfter time.Time) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestParsePEMCertificates(t *testing.T) {
+	now := time.Now()
+	ca1, key := newTestCertificate(t, "ca-1", true, now.Add(-time.Hour), now.Add(365*24*time.Hour))
+	ca2, _ := newTestCertificate(t, "ca-2", true, now.Add(-time.Hour), now.Add(365*24*time.Hour))
+
+	certs, err := parsePEMCertificates(ca1 + "\n" + ca2)
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	assert.Equal(t, "ca-2", certs[1].Subject.CommonName)
+
+	for name, data := range map[string]string{
+		"empty":        "",
+		"not PEM":      "ca-cert-data",
+		"private key":  ca1 + key,
+		"trailing":     ca1 + "garbage",
+		"invalid cert": "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n",
+	} {
+		_, err := parsePEMCertificates(data)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestCheckCertificates(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	parse := func(pemData string) []*x509.Certificate {
+		certs, err := parsePEMCertificates(pemData)
+		require.NoError(t, err)
+		return certs
+	}
+	valid, _ := newTestCertificate(t, "valid", true, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+	expiring, _ := newTestCertificate(t, "expiring", true, now.Add(-time.Hour), now.AddDate(0, 0, 10))
+	expired, _ := newTestCertificate(t, "expired", true, now.AddDate(-1, 0, 0), now.Add(-time.Hour))
+	future, _ := newTestCertificate(t, "future", true, now.Add(time.Hour), now.AddDate(1, 0, 0))
+	leaf, _ := newTestCertificate(t, "leaf", false, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+
+	ws, es := checkCertificates(parse(valid), true, now)
+	assert.Empty(t, ws)
+	assert.Empty(t, es)
+
+	ws, es = checkCertificates(parse(valid+expiring), true, now)
+	assert.Empty(t, es)
+	require.Len(t, ws, 1)
+	assert.Contains(t, ws[0], `certificate 2 ("CN=expiring") expires at 2026-01-11T00:00:00Z (within 30 days)`)
+
+	// The validity period depends on the time of the plan, so it only results in warnings
+	ws, es = checkCertificates(parse(expired), true, now)
+	assert.Empty(t, es)
+	require.Len(t, ws, 1)
+	assert.Contains(t, ws[0], "expired at 2025-12-31T23:00:00Z")
+
+	ws, es = checkCertificates(parse(future), true, now)
+	assert.Empty(t, es)
+	require.Len(t, ws, 1)
+	assert.Contains(t, ws[0], "isn't valid before")
+
+	_, es = checkCertificates(parse(leaf), true, now)
+	require.Len(t, es, 1)
+	assert.Contains(t, es[0].Error(), "isn't a CA certificate")
+	_, es = checkCertificates(parse(leaf), false, now)
+	assert.Empty(t, es)
+}
+
+func TestValidateCACertificatesPEM(t *testing.T) {
+	now := time.Now()
+	ca, _ := newTestCertificate(t, "ca", true, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+	leaf, _ := newTestCertificate(t, "leaf", false, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+
+	ws, es := validateCACertificatesPEM("", "ca_certificates")
+	assert.Empty(t, ws)
+	assert.Empty(t, es)
+	_, es = validateCACertificatesPEM(ca, "ca_certificates")
+	assert.Empty(t, es)
+	_, es = validateCACertificatesPEM(leaf, "ca_certificates")
+	require.Len(t, es, 1)
+	assert.True(t, strings.HasPrefix(es[0].Error(), "ca_certificates: "))
+	_, es = validateCACertificatesPEM("ca-cert-data", "ca_certificates")
+	require.Len(t, es, 1)
+	assert.Equal(t, "ca_certificates contains no PEM encoded certificate", es[0].Error())
+}
+
+func TestValidatePrivateKeyPEM(t *testing.T) {
+	now := time.Now()
+	cert, key := newTestCertificate(t, "leaf", false, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+
+	_, es := validatePrivateKeyPEM(key, "key_pem")
+	assert.Empty(t, es)
+	_, es = validatePrivateKeyPEM(cert, "key_pem")
+	require.Len(t, es, 1)
+	assert.Contains(t, es[0].Error(), `"CERTIFICATE" PEM block`)
+	_, es = validatePrivateKeyPEM("key", "key_pem")
+	require.Len(t, es, 1)
+}
+
+func TestValidateCertificateKeyPair(t *testing.T) {
+	now := time.Now()
+	cert, key := newTestCertificate(t, "leaf", false, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+	_, otherKey := newTestCertificate(t, "other", false, now.Add(-time.Hour), now.AddDate(1, 0, 0))
+
+	assert.NoError(t, validateCertificateKeyPair(cert, key))
+	err := validateCertificateKeyPair(cert, otherKey)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't match the certificate")
+}
+
+func TestCertificatesInfo(t *testing.T) {
+	first := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	ca1, _ := newTestCertificate(t, "ca-1", true, first.AddDate(-1, 0, 0), first.AddDate(1, 0, 0))
+	ca2, _ := newTestCertificate(t, "ca-2", true, first.AddDate(-1, 0, 0), first)
+
+	notAfter, fingerprints := certificatesInfo(ca1 + ca2)
+	assert.Equal(t, "2027-01-01T00:00:00Z", notAfter)
+	require.Len(t, fingerprints, 2)
+	certs, err := parsePEMCertificates(ca1)
+	require.NoError(t, err)
+	assert.Equal(t, certificateFingerprint(certs[0]), fingerprints[0])
+	assert.Len(t, fingerprints[0], 32*3-1)
+	assert.Regexp(t, `^([0-9A-F]{2}:){31}[0-9A-F]{2}$`, fingerprints[0])
+
+	notAfter, fingerprints = certificatesInfo("ca-cert-data")
+	assert.Equal(t, "", notAfter)
+	assert.Equal(t, []string{}, fingerprints)
+}
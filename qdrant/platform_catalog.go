@@ -0,0 +1,158 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	qcPlatform "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/platform/v1"
+)
+
+// platformCache caches the cloud providers (per account) and their regions (per account and cloud provider)
+// for the duration of a provider run, so plan-time validations don't list them for every resource.
+type platformCache struct {
+	providers *catalogCache[[]*qcPlatform.CloudProvider]
+	regions   *catalogCache[[]*qcPlatform.CloudProviderRegion]
+}
+
+// newPlatformCache creates an empty platform cache.
+func newPlatformCache() *platformCache {
+	return &platformCache{
+		providers: newCatalogCache[[]*qcPlatform.CloudProvider](),
+		regions:   newCatalogCache[[]*qcPlatform.CloudProviderRegion](),
+	}
+}
+
+// listCloudProviders returns the cloud providers for the provided account.
+// The cloud providers are fetched from the API once per account, subsequent calls return the cached result.
+// A nil cache fetches the cloud providers for every call.
+func (c *platformCache) listCloudProviders(ctx context.Context, client qcPlatform.PlatformServiceClient, accountID string) ([]*qcPlatform.CloudProvider, error) {
+	fetch := func() ([]*qcPlatform.CloudProvider, error) {
+		var trailer metadata.MD
+		resp, err := client.ListCloudProviders(ctx, &qcPlatform.ListCloudProvidersRequest{
+			AccountId: accountID,
+		}, grpc.Trailer(&trailer))
+		if err != nil {
+			return nil, fmt.Errorf("error listing cloud providers%s: %w", getRequestID(trailer), err)
+		}
+		return resp.GetItems(), nil
+	}
+	if c == nil {
+		return fetch()
+	}
+	return c.providers.get(accountID, fetch)
+}
+
+// listCloudProviderRegions returns the regions of the provided cloud provider for the provided account.
+// The regions are fetched from the API once per account and cloud provider, subsequent calls return the cached result.
+// A nil cache fetches the regions for every call.
+func (c *platformCache) listCloudProviderRegions(ctx context.Context, client qcPlatform.PlatformServiceClient, accountID, cloudProvider string) ([]*qcPlatform.CloudProviderRegion, error) {
+	fetch := func() ([]*qcPlatform.CloudProviderRegion, error) {
+		var trailer metadata.MD
+		resp, err := client.ListCloudProviderRegions(ctx, &qcPlatform.ListCloudProviderRegionsRequest{
+			AccountId:       accountID,
+			CloudProviderId: cloudProvider,
+		}, grpc.Trailer(&trailer))
+		if err != nil {
+			return nil, fmt.Errorf("error listing cloud provider regions%s: %w", getRequestID(trailer), err)
+		}
+		return resp.GetItems(), nil
+	}
+	if c == nil {
+		return fetch()
+	}
+	return c.regions.get(strings.Join([]string{accountID, cloudProvider}, "/"), fetch)
+}
+
+// getPlatformCache returns the platform cache of the provider (or nil if not available).
+func getPlatformCache(m interface{}) *platformCache {
+	clientConfig, ok := m.(*ProviderConfig)
+	if !ok {
+		return nil
+	}
+	return clientConfig.platformCache
+}
+
+// validateCloudProvider ensures the cloud provider exists and is available.
+func validateCloudProvider(providers []*qcPlatform.CloudProvider, cloudProvider string) error {
+	ids := make([]string, 0, len(providers))
+	for _, p := range providers {
+		if p.GetId() == cloudProvider {
+			if !p.GetAvailable() {
+				return fmt.Errorf("cloud provider %q (%s) is not available", cloudProvider, p.GetName())
+			}
+			return nil
+		}
+		if p.GetAvailable() {
+			ids = append(ids, p.GetId())
+		}
+	}
+	sort.Strings(ids)
+	return fmt.Errorf("cloud provider %q does not exist, must be one of: %s", cloudProvider, strings.Join(ids, ", "))
+}
+
+// validateCloudProviderRegion ensures the region exists for the cloud provider and is available.
+func validateCloudProviderRegion(regions []*qcPlatform.CloudProviderRegion, cloudProvider, cloudRegion string) error {
+	ids := make([]string, 0, len(regions))
+	for _, r := range regions {
+		if r.GetId() == cloudRegion {
+			if !r.GetAvailable() {
+				return fmt.Errorf("region %q (%s) of cloud provider %q is not available", cloudRegion, r.GetName(), cloudProvider)
+			}
+			return nil
+		}
+		if r.GetAvailable() {
+			ids = append(ids, r.GetId())
+		}
+	}
+	sort.Strings(ids)
+	return fmt.Errorf("region %q does not exist for cloud provider %q, must be one of: %s", cloudRegion, cloudProvider, strings.Join(ids, ", "))
+}
+
+// validateClusterCloudRegion validates the cloud provider and region of the cluster against the platform during plan.
+// Hybrid cloud clusters (of which the region is a hybrid cloud environment) and unknown values are not validated.
+// If the platform cannot be queried, the cluster is validated by the API during apply.
+func validateClusterCloudRegion(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChanges(clusterCloudProviderFieldName, clusterCloudRegionFieldName) {
+		return nil
+	}
+	if !d.NewValueKnown(clusterCloudProviderFieldName) {
+		return nil
+	}
+	cloudProvider := d.Get(clusterCloudProviderFieldName).(string)
+	if cloudProvider == hybridCloudClusterID {
+		return nil
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return nil
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, qcPlatform.NewPlatformServiceClient)
+	if diags.HasError() {
+		return nil
+	}
+	cache := getPlatformCache(m)
+	providers, err := cache.listCloudProviders(clientCtx, client, accountUUID.String())
+	if err != nil {
+		return nil
+	}
+	if err := validateCloudProvider(providers, cloudProvider); err != nil {
+		return fmt.Errorf("%s: %w", clusterCloudProviderFieldName, err)
+	}
+	if !d.NewValueKnown(clusterCloudRegionFieldName) {
+		return nil
+	}
+	regions, err := cache.listCloudProviderRegions(clientCtx, client, accountUUID.String(), cloudProvider)
+	if err != nil {
+		return nil
+	}
+	if err := validateCloudProviderRegion(regions, cloudProvider, d.Get(clusterCloudRegionFieldName).(string)); err != nil {
+		return fmt.Errorf("%s: %w", clusterCloudRegionFieldName, err)
+	}
+	return nil
+}
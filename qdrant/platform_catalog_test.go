@@ -0,0 +1,113 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	qcPlatform "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/platform/v1"
+)
+
+type mockPlatformServiceClient struct {
+	qcPlatform.PlatformServiceClient
+	providers      []*qcPlatform.CloudProvider
+	regions        []*qcPlatform.CloudProviderRegion
+	err            error
+	providerCalls  int
+	regionRequests []*qcPlatform.ListCloudProviderRegionsRequest
+}
+
+func (m *mockPlatformServiceClient) ListCloudProviders(_ context.Context, _ *qcPlatform.ListCloudProvidersRequest, _ ...grpc.CallOption) (*qcPlatform.ListCloudProvidersResponse, error) {
+	m.providerCalls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &qcPlatform.ListCloudProvidersResponse{Items: m.providers}, nil
+}
+
+func (m *mockPlatformServiceClient) ListCloudProviderRegions(_ context.Context, in *qcPlatform.ListCloudProviderRegionsRequest, _ ...grpc.CallOption) (*qcPlatform.ListCloudProviderRegionsResponse, error) {
+	m.regionRequests = append(m.regionRequests, in)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &qcPlatform.ListCloudProviderRegionsResponse{Items: m.regions}, nil
+}
+
+func TestPlatformCache_ListOnce(t *testing.T) {
+	client := &mockPlatformServiceClient{
+		providers: []*qcPlatform.CloudProvider{{Id: "aws", Available: true}},
+		regions:   []*qcPlatform.CloudProviderRegion{{Id: "eu-central-1", Available: true}},
+	}
+	cache := newPlatformCache()
+
+	for i := 0; i < 3; i++ {
+		providers, err := cache.listCloudProviders(context.Background(), client, "account-1")
+		require.NoError(t, err)
+		assert.Len(t, providers, 1)
+		regions, err := cache.listCloudProviderRegions(context.Background(), client, "account-1", "aws")
+		require.NoError(t, err)
+		assert.Len(t, regions, 1)
+	}
+	assert.Equal(t, 1, client.providerCalls)
+	require.Len(t, client.regionRequests, 1)
+	assert.Equal(t, "aws", client.regionRequests[0].GetCloudProviderId())
+
+	// Another cloud provider is fetched separately
+	_, err := cache.listCloudProviderRegions(context.Background(), client, "account-1", "gcp")
+	require.NoError(t, err)
+	assert.Len(t, client.regionRequests, 2)
+}
+
+func TestPlatformCache_ErrorNotCached(t *testing.T) {
+	client := &mockPlatformServiceClient{err: errors.New("unavailable")}
+	cache := newPlatformCache()
+
+	_, err := cache.listCloudProviders(context.Background(), client, "account-1")
+	assert.ErrorContains(t, err, "unavailable")
+	_, err = cache.listCloudProviderRegions(context.Background(), client, "account-1", "aws")
+	assert.ErrorContains(t, err, "unavailable")
+
+	client.err = nil
+	_, err = cache.listCloudProviders(context.Background(), client, "account-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.providerCalls)
+}
+
+func TestPlatformCache_Nil(t *testing.T) {
+	client := &mockPlatformServiceClient{}
+	var cache *platformCache
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.listCloudProviders(context.Background(), client, "account-1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, client.providerCalls)
+	assert.Nil(t, getPlatformCache(nil))
+}
+
+func TestValidateCloudProvider(t *testing.T) {
+	providers := []*qcPlatform.CloudProvider{
+		{Id: "gcp", Name: "Google Cloud", Available: true},
+		{Id: "aws", Name: "Amazon Web Services", Available: true},
+		{Id: "azure", Name: "Microsoft Azure", Available: false},
+	}
+	assert.NoError(t, validateCloudProvider(providers, "aws"))
+	assert.ErrorContains(t, validateCloudProvider(providers, "azure"), `cloud provider "azure" (Microsoft Azure) is not available`)
+	assert.ErrorContains(t, validateCloudProvider(providers, "ibm"), `cloud provider "ibm" does not exist, must be one of: aws, gcp`)
+}
+
+func TestValidateCloudProviderRegion(t *testing.T) {
+	regions := []*qcPlatform.CloudProviderRegion{
+		{Id: "eu-central-1", Name: "Frankfurt", Available: true},
+		{Id: "us-east-1", Name: "N. Virginia", Available: true},
+		{Id: "eu-south-2", Name: "Spain", Available: false},
+	}
+	assert.NoError(t, validateCloudProviderRegion(regions, "aws", "eu-central-1"))
+	assert.ErrorContains(t, validateCloudProviderRegion(regions, "aws", "eu-south-2"), `region "eu-south-2" (Spain) of cloud provider "aws" is not available`)
+	assert.ErrorContains(t, validateCloudProviderRegion(regions, "aws", "europe-west3"),
+		`region "europe-west3" does not exist for cloud provider "aws", must be one of: eu-central-1, us-east-1`)
+}
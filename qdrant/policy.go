@@ -0,0 +1,331 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+const (
+	policyAllowedCloudProvidersFieldName = "allowed_cloud_providers"
+	policyDeniedCloudProvidersFieldName  = "denied_cloud_providers"
+	policyAllowedCloudRegionsFieldName   = "allowed_cloud_regions"
+	policyDeniedCloudRegionsFieldName    = "denied_cloud_regions"
+	policyAllowedPackageTiersFieldName   = "allowed_package_tiers"
+	policyDeniedPackageTiersFieldName    = "denied_package_tiers"
+	policyAllowedGpuTypesFieldName       = "allowed_gpu_types"
+	policyDeniedGpuTypesFieldName        = "denied_gpu_types"
+	policyMinQdrantVersionFieldName      = "min_qdrant_version"
+)
+
+// providerPolicy contains the guardrails (configured on provider level) which are enforced during plan.
+// An empty allow list allows everything, the deny list takes precedence over the allow list.
+type providerPolicy struct {
+	AllowedCloudProviders []string
+	DeniedCloudProviders  []string
+	AllowedCloudRegions   []string
+	DeniedCloudRegions    []string
+	AllowedPackageTiers   []string
+	DeniedPackageTiers    []string
+	AllowedGpuTypes       []string
+	DeniedGpuTypes        []string
+	MinQdrantVersion      string
+}
+
+// providerPolicySchema defines the schema of the policy block of the provider.
+func providerPolicySchema() *schema.Schema {
+	validTiers := protoEnumNames(qcBooking.PackageTier_name)
+	validGpuTypes := protoEnumNames(qcCluster.ClusterConfigurationGpuType_name)
+	stringSet := func(description string, valid []string) *schema.Schema {
+		s := &schema.Schema{
+			Description: description,
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+		if valid != nil {
+			s.Description = fmt.Sprintf("%s Must be one of: %s.", description, strings.Join(valid, ", "))
+			s.Elem = &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(valid, false)),
+			}
+		}
+		return s
+	}
+	return &schema.Schema{
+		Description: "Guardrails enforced during plan for clusters and hybrid cloud environments. An empty allow list allows everything, a deny list takes precedence over an allow list. Hybrid cloud environments are only checked against the cloud provider rules (`hybrid`), the other rules only apply to clusters.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				policyAllowedCloudProvidersFieldName: stringSet("The cloud providers clusters may be created in (e.g. `aws`, `gcp`, `azure` or `hybrid`).", nil),
+				policyDeniedCloudProvidersFieldName:  stringSet("The cloud providers clusters may not be created in.", nil),
+				policyAllowedCloudRegionsFieldName:   stringSet("The cloud regions clusters may be created in, shell patterns are supported (e.g. `eu-*`). Not applied to hybrid cloud clusters, which reside in a hybrid cloud environment.", nil),
+				policyDeniedCloudRegionsFieldName:    stringSet("The cloud regions clusters may not be created in, shell patterns are supported (e.g. `us-*`). Not applied to hybrid cloud clusters.", nil),
+				policyAllowedPackageTiersFieldName:   stringSet("The package tiers clusters may use.", validTiers),
+				policyDeniedPackageTiersFieldName:    stringSet("The package tiers clusters may not use.", validTiers),
+				policyAllowedGpuTypesFieldName:       stringSet("The GPU types clusters may use.", validGpuTypes),
+				policyDeniedGpuTypesFieldName:        stringSet("The GPU types clusters may not use.", validGpuTypes),
+				policyMinQdrantVersionFieldName: {
+					Description:      "The minimum Qdrant version of clusters (e.g. `v1.13.0`).",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validateQdrantVersion),
+				},
+			},
+		},
+	}
+}
+
+// expandProviderPolicy creates the provider policy from the provided policy block (or nil if not configured).
+func expandProviderPolicy(v []interface{}) *providerPolicy {
+	if len(v) == 0 || v[0] == nil {
+		return nil
+	}
+	item := v[0].(map[string]interface{})
+	// Sets are unordered, sort them so error messages are stable.
+	sortedStrings := func(v interface{}) []string {
+		result := setToStringSlice(v)
+		sort.Strings(result)
+		return result
+	}
+	return &providerPolicy{
+		AllowedCloudProviders: sortedStrings(item[policyAllowedCloudProvidersFieldName]),
+		DeniedCloudProviders:  sortedStrings(item[policyDeniedCloudProvidersFieldName]),
+		AllowedCloudRegions:   sortedStrings(item[policyAllowedCloudRegionsFieldName]),
+		DeniedCloudRegions:    sortedStrings(item[policyDeniedCloudRegionsFieldName]),
+		AllowedPackageTiers:   sortedStrings(item[policyAllowedPackageTiersFieldName]),
+		DeniedPackageTiers:    sortedStrings(item[policyDeniedPackageTiersFieldName]),
+		AllowedGpuTypes:       sortedStrings(item[policyAllowedGpuTypesFieldName]),
+		DeniedGpuTypes:        sortedStrings(item[policyDeniedGpuTypesFieldName]),
+		MinQdrantVersion:      item[policyMinQdrantVersionFieldName].(string),
+	}
+}
+
+// getProviderPolicy returns the policy of the provider (or nil if not configured).
+func getProviderPolicy(m interface{}) *providerPolicy {
+	clientConfig, ok := m.(*ProviderConfig)
+	if !ok {
+		return nil
+	}
+	return clientConfig.policy
+}
+
+// checkCloudProvider returns an error if the cloud provider is not allowed by the policy.
+func (p *providerPolicy) checkCloudProvider(cloudProvider string) error {
+	return checkAllowedDenied("cloud provider", cloudProvider, p.AllowedCloudProviders, p.DeniedCloudProviders)
+}
+
+// checkCloudRegion returns an error if the cloud region is not allowed by the policy.
+func (p *providerPolicy) checkCloudRegion(cloudRegion string) error {
+	return checkAllowedDenied("cloud region", cloudRegion, p.AllowedCloudRegions, p.DeniedCloudRegions)
+}
+
+// checkPackageTier returns an error if the package tier is not allowed by the policy.
+func (p *providerPolicy) checkPackageTier(tier string) error {
+	return checkAllowedDenied("package tier", tier, p.AllowedPackageTiers, p.DeniedPackageTiers)
+}
+
+// checkGpuType returns an error if the GPU type is not allowed by the policy.
+func (p *providerPolicy) checkGpuType(gpuType string) error {
+	return checkAllowedDenied("GPU type", gpuType, p.AllowedGpuTypes, p.DeniedGpuTypes)
+}
+
+// checkQdrantVersion returns an error if the version is older than the minimum version of the policy.
+// Versions which cannot be compared (e.g. "latest") are allowed.
+func (p *providerPolicy) checkQdrantVersion(version string) error {
+	if p.MinQdrantVersion == "" || version == "" {
+		return nil
+	}
+	cmp, err := compareQdrantVersions(version, p.MinQdrantVersion)
+	if err != nil || cmp >= 0 {
+		return nil
+	}
+	return fmt.Errorf("version %q is not allowed by the provider policy, the minimum version is %q", version, p.MinQdrantVersion)
+}
+
+// checkAllowedDenied returns an error if the value matches a pattern of the denied list,
+// or if the allowed list is not empty and the value doesn't match any of its patterns.
+// The patterns use shell file name pattern matching (see path.Match).
+func checkAllowedDenied(kind, value string, allowed, denied []string) error {
+	for _, pattern := range denied {
+		if matchPolicyPattern(pattern, value) {
+			return fmt.Errorf("%s %q is denied by the provider policy (%s)", kind, value, pattern)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, pattern := range allowed {
+		if matchPolicyPattern(pattern, value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %q is not allowed by the provider policy, must match one of: %s", kind, value, strings.Join(allowed, ", "))
+}
+
+// matchPolicyPattern returns true if the value matches the provided pattern, invalid patterns only match literally.
+func matchPolicyPattern(pattern, value string) bool {
+	matched, err := path.Match(pattern, value)
+	if err != nil {
+		return pattern == value
+	}
+	return matched
+}
+
+// parseQdrantVersion parses a Qdrant version (e.g. "v1.13.4" or "1.13") into its major, minor and patch numbers.
+// Pre-release and build suffixes are ignored.
+func parseQdrantVersion(v string) ([3]int, error) {
+	var result [3]int
+	s := strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return result, fmt.Errorf("invalid version %q, expected a version like v1.13.0", v)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return result, fmt.Errorf("invalid version %q, expected a version like v1.13.0", v)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+// compareQdrantVersions returns -1, 0 or 1 if version a is older than, equal to or newer than version b.
+func compareQdrantVersions(a, b string) (int, error) {
+	va, err := parseQdrantVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseQdrantVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// validateQdrantVersion is a SchemaValidateFunc that ensures the provided value is a valid Qdrant version.
+func validateQdrantVersion(v interface{}, k string) (ws []string, es []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a string", k)}
+	}
+	if _, err := parseQdrantVersion(s); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// validateClusterPolicy enforces the provider policy for new clusters, and for changes of the policy related fields
+// of existing clusters (so clusters created before the policy can still be managed).
+// Unknown values are not validated. Package tiers are validated using the booking catalog:
+// if package tiers are restricted and the tier of the package cannot be determined (e.g. the catalog cannot be fetched
+// or the package isn't part of it), the plan fails.
+func validateClusterPolicy(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	policy := getProviderPolicy(m)
+	if policy == nil {
+		return nil
+	}
+	configPrefix := fmt.Sprintf("%s.0.", configurationFieldName)
+	packageIDPath := fmt.Sprintf("%s%s.0.%s", configPrefix, nodeConfigurationFieldName, packageIDFieldName)
+	gpuTypePath := configPrefix + dbConfigGpuTypeFieldName
+	versionPath := configPrefix + clusterVersionFieldName
+	changed := func(k string) bool {
+		return d.NewValueKnown(k) && (d.Id() == "" || d.HasChange(k))
+	}
+
+	cloudProvider := d.Get(clusterCloudProviderFieldName).(string)
+	if changed(clusterCloudProviderFieldName) {
+		if err := policy.checkCloudProvider(cloudProvider); err != nil {
+			return fmt.Errorf("%s: %w", clusterCloudProviderFieldName, err)
+		}
+	}
+	// The region of a hybrid cluster is a hybrid cloud environment ID.
+	if cloudProvider != hybridCloudClusterID && changed(clusterCloudRegionFieldName) {
+		if err := policy.checkCloudRegion(d.Get(clusterCloudRegionFieldName).(string)); err != nil {
+			return fmt.Errorf("%s: %w", clusterCloudRegionFieldName, err)
+		}
+	}
+	if gpuType := d.Get(gpuTypePath).(string); gpuType != "" && changed(gpuTypePath) {
+		if err := policy.checkGpuType(gpuType); err != nil {
+			return fmt.Errorf("%s: %w", gpuTypePath, err)
+		}
+	}
+	if changed(versionPath) {
+		if err := policy.checkQdrantVersion(d.Get(versionPath).(string)); err != nil {
+			return fmt.Errorf("%s: %w", versionPath, err)
+		}
+	}
+	if len(policy.AllowedPackageTiers) == 0 && len(policy.DeniedPackageTiers) == 0 {
+		return nil
+	}
+	if cloudProvider == hybridCloudClusterID || (d.Id() != "" && !d.HasChange(packageIDPath)) {
+		return nil
+	}
+	if !d.NewValueKnown(packageIDPath) || !d.NewValueKnown(clusterCloudProviderFieldName) || !d.NewValueKnown(clusterCloudRegionFieldName) {
+		tflog.Warn(ctx, "Skipping the package tier validation of the cluster policy, the package or region is unknown during plan")
+		return nil
+	}
+	packageID := d.Get(packageIDPath).(string)
+	cloudRegion := d.Get(clusterCloudRegionFieldName).(string)
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return unknownPackageTierError(packageIDPath, packageID, err)
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, qcBooking.NewBookingServiceClient)
+	if diags.HasError() {
+		return unknownPackageTierError(packageIDPath, packageID, fmt.Errorf("%s", diags[0].Summary))
+	}
+	packages, err := getBookingPackageCache(m).listPackages(clientCtx, client, accountUUID.String(), cloudProvider, cloudRegion)
+	if err != nil {
+		return unknownPackageTierError(packageIDPath, packageID, err)
+	}
+	pkg := findBookingPackage(packages, packageID)
+	if pkg == nil {
+		return unknownPackageTierError(packageIDPath, packageID, fmt.Errorf("the package is not offered in %s/%s", cloudProvider, cloudRegion))
+	}
+	if err := policy.checkPackageTier(pkg.GetTier().String()); err != nil {
+		return fmt.Errorf("%s: package %q (%s): %w", packageIDPath, pkg.GetId(), pkg.GetName(), err)
+	}
+	return nil
+}
+
+// unknownPackageTierError returns the error reported when the package tiers are restricted by the policy,
+// but the tier of the provided package cannot be determined.
+func unknownPackageTierError(packageIDPath, packageID string, err error) error {
+	return fmt.Errorf("%s: the tier of package %q cannot be determined, which is required by the package tiers of the provider policy: %w",
+		packageIDPath, packageID, err)
+}
+
+// validateHybridCloudEnvironmentPolicy enforces the provider policy for new hybrid cloud environments,
+// which can only be created if the hybrid cloud provider is allowed. The other rules only apply to clusters.
+func validateHybridCloudEnvironmentPolicy(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	policy := getProviderPolicy(m)
+	if policy == nil || d.Id() != "" {
+		return nil
+	}
+	return policy.checkCloudProvider(hybridCloudClusterID)
+}
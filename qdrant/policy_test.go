@@ -0,0 +1,132 @@
+package qdrant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandProviderPolicy(t *testing.T) {
+	assert.Nil(t, expandProviderPolicy(nil))
+	assert.Nil(t, expandProviderPolicy([]interface{}{nil}))
+
+	policy := expandProviderPolicy([]interface{}{
+		map[string]interface{}{
+			policyAllowedCloudProvidersFieldName: schema.NewSet(schema.HashString, []interface{}{"gcp", "aws"}),
+			policyDeniedCloudProvidersFieldName:  schema.NewSet(schema.HashString, []interface{}{}),
+			policyAllowedCloudRegionsFieldName:   schema.NewSet(schema.HashString, []interface{}{"eu-*"}),
+			policyDeniedCloudRegionsFieldName:    schema.NewSet(schema.HashString, []interface{}{}),
+			policyAllowedPackageTiersFieldName:   schema.NewSet(schema.HashString, []interface{}{}),
+			policyDeniedPackageTiersFieldName:    schema.NewSet(schema.HashString, []interface{}{"PACKAGE_TIER_PREMIUM"}),
+			policyAllowedGpuTypesFieldName:       schema.NewSet(schema.HashString, []interface{}{}),
+			policyDeniedGpuTypesFieldName:        schema.NewSet(schema.HashString, []interface{}{}),
+			policyMinQdrantVersionFieldName:      "v1.13.0",
+		},
+	})
+	require.NotNil(t, policy)
+	assert.Equal(t, &providerPolicy{
+		AllowedCloudProviders: []string{"aws", "gcp"},
+		AllowedCloudRegions:   []string{"eu-*"},
+		DeniedPackageTiers:    []string{"PACKAGE_TIER_PREMIUM"},
+		MinQdrantVersion:      "v1.13.0",
+	}, policy)
+}
+
+func TestCheckAllowedDenied(t *testing.T) {
+	// Empty lists allow everything
+	assert.NoError(t, checkAllowedDenied("cloud region", "us-east-1", nil, nil))
+	// Patterns
+	assert.NoError(t, checkAllowedDenied("cloud region", "eu-central-1", []string{"eu-*", "europe-*"}, nil))
+	assert.NoError(t, checkAllowedDenied("cloud region", "europe-west3", []string{"eu-*", "europe-*"}, nil))
+	assert.ErrorContains(t, checkAllowedDenied("cloud region", "us-east-1", []string{"eu-*", "europe-*"}, nil),
+		`cloud region "us-east-1" is not allowed by the provider policy, must match one of: eu-*, europe-*`)
+	// The deny list takes precedence
+	assert.ErrorContains(t, checkAllowedDenied("cloud region", "eu-south-1", []string{"eu-*"}, []string{"eu-south-*"}),
+		`cloud region "eu-south-1" is denied by the provider policy (eu-south-*)`)
+	// Invalid patterns only match literally
+	assert.NoError(t, checkAllowedDenied("cloud provider", "[aws", []string{"[aws"}, nil))
+}
+
+func TestProviderPolicyChecks(t *testing.T) {
+	policy := &providerPolicy{
+		AllowedCloudProviders: []string{"aws", "gcp"},
+		DeniedPackageTiers:    []string{"PACKAGE_TIER_PREMIUM"},
+		AllowedGpuTypes:       []string{"CLUSTER_CONFIGURATION_GPU_TYPE_NVIDIA"},
+		MinQdrantVersion:      "v1.13.0",
+	}
+	assert.NoError(t, policy.checkCloudProvider("aws"))
+	assert.Error(t, policy.checkCloudProvider(hybridCloudClusterID))
+	assert.NoError(t, policy.checkCloudRegion("us-east-1"))
+	assert.NoError(t, policy.checkPackageTier("PACKAGE_TIER_STANDARD"))
+	assert.Error(t, policy.checkPackageTier("PACKAGE_TIER_PREMIUM"))
+	assert.NoError(t, policy.checkGpuType("CLUSTER_CONFIGURATION_GPU_TYPE_NVIDIA"))
+	assert.Error(t, policy.checkGpuType("CLUSTER_CONFIGURATION_GPU_TYPE_AMD"))
+
+	assert.NoError(t, policy.checkQdrantVersion("v1.13.0"))
+	assert.NoError(t, policy.checkQdrantVersion("v1.14.1"))
+	assert.NoError(t, policy.checkQdrantVersion(""))
+	assert.NoError(t, policy.checkQdrantVersion("latest"))
+	assert.ErrorContains(t, policy.checkQdrantVersion("v1.12.6"), `version "v1.12.6" is not allowed by the provider policy, the minimum version is "v1.13.0"`)
+}
+
+func TestCompareQdrantVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"v1.13.0", "v1.13.0", 0},
+		{"1.13", "v1.13.0", 0},
+		{"v1.13.1", "v1.13.0", 1},
+		{"v1.9.0", "v1.13.0", -1},
+		{"v2.0.0-rc1", "v1.13.0", 1},
+	}
+	for _, tt := range tests {
+		cmp, err := compareQdrantVersions(tt.a, tt.b)
+		require.NoError(t, err, tt.a)
+		assert.Equal(t, tt.expected, cmp, "%s vs %s", tt.a, tt.b)
+	}
+	_, err := compareQdrantVersions("latest", "v1.13.0")
+	assert.Error(t, err)
+}
+
+func TestValidateQdrantVersion(t *testing.T) {
+	_, es := validateQdrantVersion("v1.13.0", "min_qdrant_version")
+	assert.Empty(t, es)
+	_, es = validateQdrantVersion("v1.x", "min_qdrant_version")
+	assert.Len(t, es, 1)
+	_, es = validateQdrantVersion(1, "min_qdrant_version")
+	assert.Len(t, es, 1)
+}
+
+func TestValidateClusterPolicy_UnknownPackageTier(t *testing.T) {
+	r := resourceAccountsCluster()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":           "test-cluster",
+		"cloud_provider": "aws",
+		"cloud_region":   "eu-central-1",
+		"configuration": []interface{}{
+			map[string]interface{}{
+				"number_of_nodes": 1,
+				"node_configuration": []interface{}{
+					map[string]interface{}{"package_id": "pkg-1"},
+				},
+			},
+		},
+	})
+
+	// Without a client the catalog cannot be read, so the tier of the package is unknown.
+	_, err := r.Diff(context.Background(), nil, config, &ProviderConfig{policy: &providerPolicy{
+		AllowedPackageTiers: []string{"PACKAGE_TIER_STANDARD"},
+	}})
+	assert.ErrorContains(t, err, `the tier of package "pkg-1" cannot be determined`)
+
+	// Without package tier rules the catalog isn't needed.
+	_, err = r.Diff(context.Background(), nil, config, &ProviderConfig{policy: &providerPolicy{
+		AllowedCloudProviders: []string{"aws"},
+	}})
+	assert.NoError(t, err)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func init() {
@@ -46,6 +47,19 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: "Allow insecure gRPC connections. This is useful for development environments with self-signed certificates. Defaults to false.",
 			},
+			"max_cluster_monthly_cost": {
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+				Description:      "The maximum estimated monthly cost of a single cluster (in the currency of the booking catalog). Plans creating or growing a cluster beyond it fail, unless the cluster has the label `" + clusterBudgetOverrideLabelKey + "` set to `true`. Plans for which the cost cannot be estimated fail as well. Defaults to 0 (no limit).",
+			},
+			"max_account_monthly_cost": {
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+				Description:      "The maximum estimated monthly cost of all clusters in an account (in the currency of the booking catalog). Plans creating or growing a cluster beyond it fail, unless the cluster has the label `" + clusterBudgetOverrideLabelKey + "` set to `true`. Plans for which the cost cannot be estimated fail as well. Defaults to 0 (no limit).",
+			},
+			"policy": providerPolicySchema(),
 		},
 		// ResourcesMap defines all the resources that this provider offers.
 		ResourcesMap: map[string]*schema.Resource{
@@ -54,21 +68,30 @@ func Provider() *schema.Provider {
 			"qdrant-cloud_accounts_cluster":                  resourceAccountsCluster(),                // Resource for managing Qdrant Cloud account clusters.
 			"qdrant-cloud_accounts_backup_schedule":          resourceAccountsBackupSchedule(),         // Resource for managing Qdrant Cloud account backup schedules (for a cluster).
 			"qdrant-cloud_accounts_manual_backup":            resourceAccountsManualBackup(),           // Resource for managing Qdrant Cloud account manual backup (for a cluster).
+			"qdrant-cloud_accounts_backup_policy":            resourceAccountsBackupPolicy(),           // Resource for managing a tiered (daily/weekly/monthly) backup policy (for a cluster).
 			"qdrant-cloud_accounts_hybrid_cloud_environment": resourceAccountsHybridCloudEnvironment(), // Resource for managing Qdrant Cloud account hybrid cloud environments.
 			"qdrant-cloud_accounts_role":                     resourceAccountsRole(),                   // Resource for managing Qdrant Cloud account roles.
 			"qdrant-cloud_accounts_user_roles":               resourceAccountsUserRoles(),              // Resource for managing role assignments for a user (by email) within an account.
+			"qdrant-cloud_accounts_management_key":           resourceAccountsManagementKey(),          // Resource for managing Qdrant Cloud account management keys.
 		},
 		// DataSourcesMap defines all the data sources that this provider offers.
 		DataSourcesMap: map[string]*schema.Resource{
-			"qdrant-cloud_accounts_auth_keys":            dataSourceAccountsAuthKeys(),        // Data source for retrieving Qdrant Cloud accounts' authorization keys.
-			"qdrant-cloud_accounts_database_api_keys_v2": dataSourceAccountsAuthKeysV2(),      // Data source for retrieving Qdrant Cloud accounts' authorization keys v2.
-			"qdrant-cloud_accounts_clusters":             dataSourceAccountsClusters(),        // Data source for listing Qdrant Cloud clusters under an account.
-			"qdrant-cloud_accounts_cluster":              dataSourceAccountsCluster(),         // Data source for retrieving details of a specific Qdrant cluster.
-			"qdrant-cloud_booking_packages":              dataSourceBookingPackages(),         // Data source for Qdrant booking packages.
-			"qdrant-cloud_accounts_backup_schedules":     dataSourceAccountsBackupSchedules(), // Data source for listing Qdrant Cloud backup schedules under an account and cluster.
-			"qdrant-cloud_accounts_backup_schedule":      dataSourceAccountsBackupSchedule(),  // Data source for retrieving Qdrant Cloud accounts' backup schedules (for a cluster).
-			"qdrant-cloud_accounts_members":              dataSourceAccountsMembers(),         // Data source for listing Qdrant Cloud account members.
-			"qdrant-cloud_accounts_roles":                dataSourceAccountsRoles(),           // Data source for listing Qdrant Cloud account roles (system and custom).
+			"qdrant-cloud_accounts_auth_keys":                       dataSourceAccountsAuthKeys(),                     // Data source for retrieving Qdrant Cloud accounts' authorization keys.
+			"qdrant-cloud_accounts_database_api_keys_v2":            dataSourceAccountsAuthKeysV2(),                   // Data source for retrieving Qdrant Cloud accounts' authorization keys v2.
+			"qdrant-cloud_accounts_clusters":                        dataSourceAccountsClusters(),                     // Data source for listing Qdrant Cloud clusters under an account.
+			"qdrant-cloud_accounts_cluster":                         dataSourceAccountsCluster(),                      // Data source for retrieving details of a specific Qdrant cluster.
+			"qdrant-cloud_accounts_cost_report":                     dataSourceAccountsCostReport(),                   // Data source for estimating the cost of all Qdrant clusters under an account.
+			"qdrant-cloud_booking_packages":                         dataSourceBookingPackages(),                      // Data source for Qdrant booking packages.
+			"qdrant-cloud_booking_package":                          dataSourceBookingPackage(),                       // Data source for selecting the cheapest Qdrant booking package meeting the requirements.
+			"qdrant-cloud_cloud_providers":                          dataSourceCloudProviders(),                       // Data source for listing the cloud providers clusters can be created in.
+			"qdrant-cloud_cloud_provider_regions":                   dataSourceCloudProviderRegions(),                 // Data source for listing the regions of a cloud provider clusters can be created in.
+			"qdrant-cloud_accounts_backup_schedules":                dataSourceAccountsBackupSchedules(),              // Data source for listing Qdrant Cloud backup schedules under an account and cluster.
+			"qdrant-cloud_accounts_backup_schedule":                 dataSourceAccountsBackupSchedule(),               // Data source for retrieving Qdrant Cloud accounts' backup schedules (for a cluster).
+			"qdrant-cloud_accounts_members":                         dataSourceAccountsMembers(),                      // Data source for listing Qdrant Cloud account members.
+			"qdrant-cloud_accounts_roles":                           dataSourceAccountsRoles(),                        // Data source for listing Qdrant Cloud account roles (system and custom).
+			"qdrant-cloud_accounts_hybrid_cloud_environments":       dataSourceAccountsHybridCloudEnvironments(),      // Data source for listing Qdrant Cloud hybrid cloud environments under an account.
+			"qdrant-cloud_accounts_hybrid_cloud_environment":        dataSourceAccountsHybridCloudEnvironment(),       // Data source for retrieving a specific Qdrant Cloud hybrid cloud environment (by ID or name).
+			"qdrant-cloud_accounts_hybrid_cloud_environment_health": dataSourceAccountsHybridCloudEnvironmentHealth(), // Data source for retrieving the health of a Qdrant Cloud hybrid cloud environment.
 		},
 		// ConfigureContextFunc points to the function used to configure the runtime environment of the provider.
 		ConfigureContextFunc: providerConfigure,
@@ -89,6 +112,9 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		accountID = aid.(string)
 	}
 	insecure := d.Get("insecure").(bool)
+	maxClusterMonthlyCost := d.Get("max_cluster_monthly_cost").(float64)
+	maxAccountMonthlyCost := d.Get("max_account_monthly_cost").(float64)
+	policy := expandProviderPolicy(d.Get("policy").([]interface{}))
 	var diags diag.Diagnostics
 
 	// Validate that the API key is not empty, returning an error diagnostic if it is.
@@ -112,6 +138,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		BaseURL:   apiURL,
 		AccountID: accountID,
 		Insecure:  insecure,
+
+		MaxClusterMonthlyCost: maxClusterMonthlyCost,
+		MaxAccountMonthlyCost: maxAccountMonthlyCost,
+
+		packageCache:  newBookingPackageCache(),
+		platformCache: newPlatformCache(),
+		policy:        policy,
 	}
 
 	return &config, diags
@@ -125,4 +158,11 @@ type ProviderConfig struct {
 	BaseURL   string // BaseURL is the root URL for all API requests, typically pointing to the Qdrant Cloud API endpoint.
 	AccountID string // The default Account Identifier for the Qdrant cloud, if any
 	Insecure  bool   // Insecure allows for insecure gRPC connections, useful for development.
+
+	MaxClusterMonthlyCost float64 // The maximum estimated monthly cost of a single cluster (0 means no limit).
+	MaxAccountMonthlyCost float64 // The maximum estimated monthly cost of all clusters in an account (0 means no limit).
+
+	packageCache  *bookingPackageCache // Caches the booking packages during a provider run (used for plan-time validation).
+	platformCache *platformCache       // Caches the cloud providers and regions during a provider run (used for plan-time validation).
+	policy        *providerPolicy      // The guardrails enforced during plan (nil if not configured).
 }
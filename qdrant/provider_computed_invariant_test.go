@@ -25,6 +25,12 @@ var computedInvariantAllowlist = map[string]string{
 	"qdrant-cloud_accounts_backup_schedule.retention_period": reasonUnconfirmedBackend,
 	"qdrant-cloud_accounts_manual_backup.retention_period":   reasonUnconfirmedBackend,
 	"qdrant-cloud_accounts_role.description":                 reasonUnconfirmedBackend,
+
+	// Client-side only fields: never sent to (or returned by) the backend.
+	"qdrant-cloud_accounts_cluster.final_backup.retention_period":         "client-side only: used for the backup taken on destroy",
+	"qdrant-cloud_accounts_cluster.backup_before_update.retention_period": "client-side only: used for the backup taken before a disruptive update",
+	"qdrant-cloud_accounts_management_key.rotate_after":                   "client-side only: rotation trigger, changing it replaces the key",
+	"qdrant-cloud_accounts_cluster.tls_material.key_pem":                  "client-side only: write-only, never stored in the state",
 }
 
 // TestProviderOptionalConfigFieldsAreComputed is the provider-wide generalization
@@ -0,0 +1,248 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	backupv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+)
+
+// resourceAccountsBackupPolicy constructs a Terraform resource for managing a tiered (daily/weekly/monthly)
+// backup policy of a cluster. Each configured tier is expanded into a backup schedule.
+// Returns a schema.Resource pointer configured with schema definitions and the CRUD functions.
+func resourceAccountsBackupPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Backup Policy Resource (tiered daily/weekly/monthly backup schedules for a cluster).",
+		CreateContext: resourceBackupPolicyCreate,
+		ReadContext:   resourceBackupPolicyRead,
+		UpdateContext: resourceBackupPolicyUpdate,
+		DeleteContext: resourceBackupPolicyDelete,
+		Schema:        accountsBackupPolicySchema(),
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			// Reconcile if the schedules (as read from the server) don't match the configured tiers.
+			if d.Id() != "" && !backupPolicySchedulesInSync(expandBackupPolicySchedules(d), readBackupPolicySchedules(d)) {
+				return d.SetNewComputed(backupPolicySchedulesFieldName)
+			}
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importBackupPolicyState,
+		},
+	}
+}
+
+// importBackupPolicyState imports the backup policy of the cluster with the provided ID,
+// by reconstructing the tiers from the existing backup schedules of the cluster.
+func importBackupPolicyState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clusterID := d.Id()
+	if clusterID == "" || strings.Contains(clusterID, "/") {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected <cluster_id>", clusterID)
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return nil, err
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, backupv1.NewBackupServiceClient)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+	var trailer metadata.MD
+	resp, err := client.ListBackupSchedules(clientCtx, &backupv1.ListBackupSchedulesRequest{
+		AccountId: accountUUID.String(),
+		ClusterId: newPointer(clusterID),
+	}, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, fmt.Errorf("error listing backup schedules%s: %w", getRequestID(trailer), err)
+	}
+	imported, err := flattenImportedBackupPolicy(clusterID, resp.GetItems())
+	if err != nil {
+		return nil, err
+	}
+	imported[backupPolicyAccountIDFieldName] = accountUUID.String()
+	imported[backupPolicyClusterIDFieldName] = clusterID
+	imported[backupPolicyDeleteBackupsOnDestroy] = true
+	for k, v := range imported {
+		if err := d.Set(k, v); err != nil {
+			return nil, fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceBackupPolicyCreate creates a backup schedule for every configured tier.
+func resourceBackupPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error creating backup policy"
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	if err := d.Set(backupPolicyAccountIDFieldName, accountUUID.String()); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// The policy is identified by the cluster, a cluster has a single policy.
+	d.SetId(d.Get(backupPolicyClusterIDFieldName).(string))
+	if diags := reconcileBackupPolicy(ctx, d, m, errorPrefix); diags.HasError() {
+		return diags
+	}
+	return resourceBackupPolicyRead(ctx, d, m)
+}
+
+// resourceBackupPolicyRead refreshes the backup schedules managed by the policy.
+// Schedules removed outside of Terraform are dropped from the state, so they will be recreated.
+func resourceBackupPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error reading backup policy"
+	client, clientCtx, diags := getServiceClient(ctx, m, backupv1.NewBackupServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+
+	current := readBackupPolicySchedules(d)
+	for tier, schedule := range current {
+		var trailer metadata.MD
+		resp, err := client.GetBackupSchedule(clientCtx, &backupv1.GetBackupScheduleRequest{
+			AccountId:        accountUUID.String(),
+			ClusterId:        d.Get(backupPolicyClusterIDFieldName).(string),
+			BackupScheduleId: schedule.ID,
+		}, grpc.Trailer(&trailer))
+		if err != nil {
+			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+				delete(current, tier)
+				continue
+			}
+			return diag.FromErr(fmt.Errorf("%s%s: %w", errorPrefix, getRequestID(trailer), err))
+		}
+		current[tier] = newBackupPolicySchedule(tier, resp.GetBackupSchedule())
+	}
+
+	if err := d.Set(backupPolicySchedulesFieldName, flattenBackupPolicySchedules(current)); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	return nil
+}
+
+// resourceBackupPolicyUpdate reconciles the backup schedules with the configured tiers.
+func resourceBackupPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := reconcileBackupPolicy(ctx, d, m, "error updating backup policy"); diags.HasError() {
+		return diags
+	}
+	return resourceBackupPolicyRead(ctx, d, m)
+}
+
+// resourceBackupPolicyDelete deletes all backup schedules managed by the policy.
+func resourceBackupPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error deleting backup policy"
+	client, clientCtx, diags := getServiceClient(ctx, m, backupv1.NewBackupServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	deleteBackups := d.Get(backupPolicyDeleteBackupsOnDestroy).(bool)
+	for _, schedule := range readBackupPolicySchedules(d) {
+		if err := deleteBackupPolicySchedule(client, clientCtx, accountUUID.String(), schedule.ID, deleteBackups); err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// reconcileBackupPolicy creates, updates and deletes backup schedules, so they match the configured tiers.
+// The state is updated after every change, so a partial failure doesn't leak schedules.
+func reconcileBackupPolicy(ctx context.Context, d *schema.ResourceData, m interface{}, errorPrefix string) diag.Diagnostics {
+	client, clientCtx, diags := getServiceClient(ctx, m, backupv1.NewBackupServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	clusterID := d.Get(backupPolicyClusterIDFieldName).(string)
+	deleteBackups := d.Get(backupPolicyDeleteBackupsOnDestroy).(bool)
+
+	desired := expandBackupPolicySchedules(d)
+	current := readBackupPolicySchedules(d)
+	save := func() diag.Diagnostics {
+		if err := d.Set(backupPolicySchedulesFieldName, flattenBackupPolicySchedules(current)); err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+		return nil
+	}
+
+	for _, tier := range backupPolicyTiers {
+		want, wanted := desired[tier]
+		have, exists := current[tier]
+		switch {
+		case !wanted && exists:
+			// Tier has been removed
+			if err := deleteBackupPolicySchedule(client, clientCtx, accountUUID.String(), have.ID, deleteBackups); err != nil {
+				return append(save(), diag.FromErr(fmt.Errorf("%s (%s): %w", errorPrefix, tier, err))...)
+			}
+			delete(current, tier)
+		case wanted && !exists:
+			// Tier has been added
+			var trailer metadata.MD
+			resp, err := client.CreateBackupSchedule(clientCtx, &backupv1.CreateBackupScheduleRequest{
+				BackupSchedule: &backupv1.BackupSchedule{
+					AccountId:       accountUUID.String(),
+					ClusterId:       clusterID,
+					Schedule:        want.CronExpression,
+					RetentionPeriod: parseDuration(want.RetentionPeriod),
+				},
+			}, grpc.Trailer(&trailer))
+			if err != nil {
+				return append(save(), diag.FromErr(fmt.Errorf("%s (%s)%s: %w", errorPrefix, tier, getRequestID(trailer), err))...)
+			}
+			current[tier] = newBackupPolicySchedule(tier, resp.GetBackupSchedule())
+		case wanted && (want.CronExpression != have.CronExpression || want.RetentionPeriod != have.RetentionPeriod):
+			// Tier has been changed
+			var trailer metadata.MD
+			resp, err := client.UpdateBackupSchedule(clientCtx, &backupv1.UpdateBackupScheduleRequest{
+				BackupSchedule: &backupv1.BackupSchedule{
+					Id:              have.ID,
+					AccountId:       accountUUID.String(),
+					ClusterId:       clusterID,
+					Schedule:        want.CronExpression,
+					RetentionPeriod: parseDuration(want.RetentionPeriod),
+				},
+			}, grpc.Trailer(&trailer))
+			if err != nil {
+				return append(save(), diag.FromErr(fmt.Errorf("%s (%s)%s: %w", errorPrefix, tier, getRequestID(trailer), err))...)
+			}
+			current[tier] = newBackupPolicySchedule(tier, resp.GetBackupSchedule())
+		}
+	}
+	return save()
+}
+
+// deleteBackupPolicySchedule deletes a single backup schedule, ignoring schedules which are already gone.
+func deleteBackupPolicySchedule(client backupv1.BackupServiceClient, clientCtx context.Context, accountID, scheduleID string, deleteBackups bool) error {
+	var trailer metadata.MD
+	_, err := client.DeleteBackupSchedule(clientCtx, &backupv1.DeleteBackupScheduleRequest{
+		AccountId:        accountID,
+		BackupScheduleId: scheduleID,
+		DeleteBackups:    newPointer(deleteBackups),
+	}, grpc.Trailer(&trailer))
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return nil
+		}
+		return fmt.Errorf("deleting backup schedule %s%s: %w", scheduleID, getRequestID(trailer), err)
+	}
+	return nil
+}
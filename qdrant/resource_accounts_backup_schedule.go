@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,6 +24,16 @@ func resourceAccountsBackupSchedule() *schema.Resource {
 		UpdateContext: resourceBackupScheduleUpdate,
 		DeleteContext: resourceBackupScheduleDelete,
 		Schema:        accountsBackupScheduleResourceSchema(false),
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			// The next runs are recalculated on apply, once the (new) schedule is known.
+			if d.HasChange(backupScheduleCronExpressionFieldName) {
+				return d.SetNewComputed(backupScheduleNextRunsFieldName)
+			}
+			return nil
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateBackupScheduleRetentionPeriod,
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				parts := strings.Split(d.Id(), "/")
@@ -66,6 +77,17 @@ func resourceBackupScheduleCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	d.SetId(resp.GetBackupSchedule().GetId())
+	// A schedule is created active, pause it if requested.
+	if resp.GetBackupSchedule().GetStatus() != schedule.GetStatus() {
+		schedule.Id = d.Id()
+		trailer = metadata.MD{}
+		_, err := client.UpdateBackupSchedule(clientCtx, &backupv1.UpdateBackupScheduleRequest{
+			BackupSchedule: schedule,
+		}, grpc.Trailer(&trailer))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("%s%s: %w", errorPrefix, getRequestID(trailer), err))
+		}
+	}
 	return resourceBackupScheduleRead(ctx, d, m)
 }
 
@@ -96,6 +118,7 @@ func resourceBackupScheduleRead(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	flattened := flattenBackupSchedule(resp.GetBackupSchedule())
+	flattened[backupScheduleNextRunsFieldName] = readBackupScheduleNextRuns(d, resp.GetBackupSchedule().GetSchedule(), time.Now())
 	for k, v := range flattened {
 		if err := d.Set(k, v); err != nil {
 			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
@@ -105,6 +128,17 @@ func resourceBackupScheduleRead(ctx context.Context, d *schema.ResourceData, m i
 	return nil
 }
 
+// readBackupScheduleNextRuns returns the next runs of the schedule to store in the state.
+// They are only recalculated (from now) when the cron expression is created, imported or changed,
+// so they are anchored at the last change of the cron expression and a refresh doesn't report drift.
+func readBackupScheduleNextRuns(d *schema.ResourceData, cronExpression string, now time.Time) []interface{} {
+	runs, _ := d.Get(backupScheduleNextRunsFieldName).([]interface{})
+	if len(runs) > 0 && !d.HasChange(backupScheduleCronExpressionFieldName) && d.Get(backupScheduleCronExpressionFieldName).(string) == cronExpression {
+		return runs
+	}
+	return flattenBackupScheduleNextRuns(cronExpression, now)
+}
+
 func resourceBackupScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	errorPrefix := "error updating backup schedule"
 	client, clientCtx, diags := getServiceClient(ctx, m, backupv1.NewBackupServiceClient)
@@ -116,7 +150,7 @@ func resourceBackupScheduleUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 	}
 
-	if d.HasChanges(backupScheduleCronExpressionFieldName, backupScheduleRetentionPeriodFieldName) {
+	if d.HasChanges(backupScheduleCronExpressionFieldName, backupScheduleRetentionPeriodFieldName, backupScheduleEnabledFieldName) {
 		schedule := expandBackupSchedule(d)
 		schedule.AccountId = accountUUID.String()
 
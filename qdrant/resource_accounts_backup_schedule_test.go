@@ -3,9 +3,13 @@ package qdrant
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccResourceAccountsBackupSchedule(t *testing.T) {
@@ -83,12 +87,22 @@ resource "qdrant-cloud_accounts_backup_schedule" "test" {
 		resource.TestCheckResourceAttr("qdrant-cloud_accounts_backup_schedule.test", "cron_expression", "0 0 1 * *"),
 		resource.TestCheckResourceAttr("qdrant-cloud_accounts_backup_schedule.test", "retention_period", "168h0m0s"),
 		resource.TestCheckResourceAttrSet("qdrant-cloud_accounts_backup_schedule.test", "id"),
+		resource.TestCheckResourceAttr("qdrant-cloud_accounts_backup_schedule.test", "enabled", "true"),
+	)
+
+	// Pause the schedule, without deleting it.
+	pausedConfig := strings.Replace(config, `retention_period = "168h"      # 7 days`, `retention_period = "168h"      # 7 days
+	enabled          = false`, 1)
+	pausedCheck := resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr("qdrant-cloud_accounts_backup_schedule.test", "enabled", "false"),
+		resource.TestCheckResourceAttr("qdrant-cloud_accounts_backup_schedule.test", "status", "BACKUP_SCHEDULE_STATUS_DISABLED"),
 	)
 
 	resource.Test(t, resource.TestCase{
 		ProviderFactories: providerFactories,
 		Steps: []resource.TestStep{
 			{Config: config, Check: check},
+			{Config: pausedConfig, Check: pausedCheck},
 		},
 	})
 }
@@ -186,3 +200,34 @@ resource "qdrant-cloud_accounts_backup_schedule" "test" {
 		},
 	})
 }
+
+func TestReadBackupScheduleNextRuns(t *testing.T) {
+	now := time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC)
+	stored := func(cronExpression string, runs ...string) *terraform.InstanceState {
+		attributes := map[string]string{
+			backupScheduleCronExpressionFieldName:  cronExpression,
+			backupScheduleNextRunsFieldName + ".#": fmt.Sprintf("%d", len(runs)),
+		}
+		for i, run := range runs {
+			attributes[fmt.Sprintf("%s.%d", backupScheduleNextRunsFieldName, i)] = run
+		}
+		return &terraform.InstanceState{ID: "schedule-1", Attributes: attributes}
+	}
+
+	t.Run("kept on refresh", func(t *testing.T) {
+		d := resourceAccountsBackupSchedule().Data(stored("0 12 * * *", "2024-01-01T12:00:00Z"))
+		assert.Equal(t, []interface{}{"2024-01-01T12:00:00Z"}, readBackupScheduleNextRuns(d, "0 12 * * *", now))
+	})
+	t.Run("recalculated when changed outside of Terraform", func(t *testing.T) {
+		d := resourceAccountsBackupSchedule().Data(stored("0 12 * * *", "2024-01-01T12:00:00Z"))
+		runs := readBackupScheduleNextRuns(d, "0 6 * * *", now)
+		assert.Len(t, runs, backupScheduleNextRunsCount)
+		assert.Equal(t, "2024-05-11T06:00:00Z", runs[0])
+	})
+	t.Run("calculated on import", func(t *testing.T) {
+		d := resourceAccountsBackupSchedule().Data(stored(""))
+		runs := readBackupScheduleNextRuns(d, "0 12 * * *", now)
+		assert.Len(t, runs, backupScheduleNextRunsCount)
+		assert.Equal(t, "2024-05-10T12:00:00Z", runs[0])
+	})
+}
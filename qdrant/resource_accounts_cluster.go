@@ -6,7 +6,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"google.golang.org/grpc"
@@ -14,12 +16,16 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+	qcb "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
 	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
 )
 
 const (
 	clusterCreatePollInterval = 10 * time.Second
 	clusterCreateTimeout      = 20 * time.Minute
+	clusterUpdateTimeout      = 60 * time.Minute
+	clusterDeleteTimeout      = 60 * time.Minute
 )
 
 // resourceAccountsCluster constructs a Terraform resource for
@@ -33,15 +39,93 @@ func resourceAccountsCluster() *schema.Resource {
 		UpdateContext: resourceClusterUpdate,
 		DeleteContext: resourceClusterDelete,
 		Schema:        accountsClusterSchema(false),
+		CustomizeDiff: customdiff.All(
+			validateClusterCloudRegion,
+			validateClusterBookingPackage,
+			validateClusterHybridStorage,
+			validateClusterPolicy,
+			planClusterTlsMaterial,
+			setClusterCostEstimate,
+			enforceClusterBudget,
+			setClusterChangeImpact,
+			planClusterPreUpdateBackupID,
+		),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateClusterTlsKeyPair,
+		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importClusterState,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(clusterCreateTimeout),
+			Update: schema.DefaultTimeout(clusterUpdateTimeout),
+			Delete: schema.DefaultTimeout(clusterDeleteTimeout),
 		},
 	}
 }
 
+// validateClusterBookingPackage validates the package, additional resources and storage tier of the cluster
+// against the booking catalog (ListPackages), so invalid combinations are reported during plan instead of apply.
+// Existing clusters are only validated if one of the related fields changes.
+func validateClusterBookingPackage(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	nodeConfigPrefix := fmt.Sprintf("%s.0.%s.0.", configurationFieldName, nodeConfigurationFieldName)
+	packageIDPath := nodeConfigPrefix + packageIDFieldName
+	resourceConfigurationsPath := nodeConfigPrefix + resourceConfigurationsFieldName
+	storageTierTypePath := fmt.Sprintf("%s.0.%s.0.%s", configurationFieldName, clusterStorageConfigurationFieldName, clusterStorageTierTypeFieldName)
+
+	if d.Id() != "" && !d.HasChanges(clusterCloudProviderFieldName, clusterCloudRegionFieldName, packageIDPath, resourceConfigurationsPath, storageTierTypePath) {
+		return nil
+	}
+	// The additional resource types can be validated without the catalog.
+	resourceConfigurations, _ := d.Get(resourceConfigurationsPath).([]interface{})
+	if d.NewValueKnown(resourceConfigurationsPath) {
+		if err := validateClusterAdditionalResources(nil, resourceConfigurations); err != nil {
+			return fmt.Errorf("%s: %w", resourceConfigurationsPath, err)
+		}
+	}
+	for _, k := range []string{clusterCloudProviderFieldName, clusterCloudRegionFieldName, packageIDPath} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+	cloudProvider := d.Get(clusterCloudProviderFieldName).(string)
+	cloudRegion := d.Get(clusterCloudRegionFieldName).(string)
+	packageID := d.Get(packageIDPath).(string)
+	// The region of a hybrid cluster is a hybrid cloud environment, which isn't part of the catalog.
+	if packageID == "" || cloudProvider == hybridCloudClusterID {
+		return nil
+	}
+	// If the catalog cannot be fetched, the cluster is validated by the API during apply.
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return nil
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, qcBooking.NewBookingServiceClient)
+	if diags.HasError() {
+		return nil
+	}
+	packages, err := getBookingPackageCache(m).listPackages(clientCtx, client, accountUUID.String(), cloudProvider, cloudRegion)
+	if err != nil {
+		return nil
+	}
+
+	pkg, err := validateClusterPackage(packages, packageID, cloudProvider, cloudRegion)
+	if err != nil {
+		return fmt.Errorf("%s: %w", packageIDPath, err)
+	}
+	if d.NewValueKnown(resourceConfigurationsPath) {
+		if err := validateClusterAdditionalResources(pkg, resourceConfigurations); err != nil {
+			return fmt.Errorf("%s: %w", resourceConfigurationsPath, err)
+		}
+	}
+	if d.NewValueKnown(storageTierTypePath) {
+		if err := validateClusterStorageTier(pkg, d.Get(storageTierTypePath).(string)); err != nil {
+			return fmt.Errorf("%s: %w", storageTierTypePath, err)
+		}
+	}
+	return nil
+}
+
 // resourceClusterRead reads the specific cluster's data from the API.
 func resourceClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	errorPrefix := "error reading cluster"
@@ -78,6 +162,13 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, m interfac
 			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 		}
 	}
+	// The impact of the applied changes is no longer relevant once refreshed, it is classified again during the next plan.
+	if err := d.Set(clusterChangeImpactFieldName, []string{}); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	if err := setClusterTlsMaterialInfo(d); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
 	return nil
 }
 
@@ -194,6 +285,23 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 	// Do not provide state in update
 	cluster.State = nil
+	// Report the impact of disruptive changes
+	diags = append(diags, clusterChangeImpactWarnings(classifyClusterChanges(d))...)
+	// Do we need to create a backup before applying a disruptive change?
+	if isPreUpdateBackupPlanned(d) {
+		_, retentionPeriod := expandClusterBackupSettings(d, clusterBackupBeforeUpdateFieldName)
+		backupClient, backupClientCtx, backupDiags := getServiceClient(ctx, m, qcb.NewBackupServiceClient)
+		if backupDiags.HasError() {
+			return backupDiags
+		}
+		backup, err := createBackupAndWait(ctx, backupClient, backupClientCtx, cluster.GetAccountId(), d.Id(), retentionPeriod, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("%s (backup before update): %w", errorPrefix, err))
+		}
+		if err := d.Set(clusterPreUpdateBackupIDFieldName, backup.GetId()); err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+	}
 	// Update the cluster
 	var trailer metadata.MD
 	resp, err := client.UpdateCluster(clientCtx, &qcCluster.UpdateClusterRequest{
@@ -226,7 +334,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 		}
 	}
-	return nil
+	return diags
 
 }
 
@@ -244,6 +352,28 @@ func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, m interf
 	}
 	// Do we need to delete the backups?
 	deleteBackups := d.Get(clusterDeleteBackupsOnDestroyFieldName).(bool)
+	// Do we need to create a final backup first?
+	if finalBackup, retentionPeriod := expandClusterBackupSettings(d, clusterFinalBackupFieldName); finalBackup {
+		backupClient, backupClientCtx, backupDiags := getServiceClient(ctx, m, qcb.NewBackupServiceClient)
+		if backupDiags.HasError() {
+			return backupDiags
+		}
+		backup, err := createBackupAndWait(ctx, backupClient, backupClientCtx, accountUUID.String(), d.Id(), retentionPeriod, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			// If the cluster is not found, it has been deleted already (nothing left to back up).
+			if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+				d.SetId("")
+				return nil
+			}
+			return diag.FromErr(fmt.Errorf("%s (final backup): %w", errorPrefix, err))
+		}
+		// Keep the backups, otherwise the final backup would be removed with the cluster.
+		deleteBackups = false
+		tflog.Info(ctx, "Final backup of the cluster created before deletion", map[string]interface{}{
+			"cluster_id": d.Id(),
+			"backup_id":  backup.GetId(),
+		})
+	}
 	// Delete the cluster
 	var trailer metadata.MD
 	_, err = client.DeleteCluster(clientCtx, &qcCluster.DeleteClusterRequest{
@@ -257,10 +387,10 @@ func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, m interf
 		// If the cluster is not found, it has been deleted already.
 		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
 			d.SetId("")
-			return nil
+			return diags
 		}
-		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		return append(diags, diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))...)
 	}
 	d.SetId("")
-	return nil
+	return diags
 }
@@ -112,6 +112,8 @@ output "cluster_cfg_num_nodes" {
 						Check: resource.ComposeTestCheckFunc(
 							resource.TestCheckOutput("cluster_name", "test-cluster"),
 							resource.TestCheckResourceAttrSet("qdrant-cloud_accounts_cluster.test", "url"),
+							resource.TestCheckResourceAttrSet("qdrant-cloud_accounts_cluster.test", "endpoint.0.rest_url"),
+							resource.TestCheckResourceAttrSet("qdrant-cloud_accounts_cluster.test", "connection_snippets.0.python"),
 						),
 					},
 					{
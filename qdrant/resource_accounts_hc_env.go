@@ -5,12 +5,15 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	qcb "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
 	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
 )
 
@@ -23,30 +26,45 @@ func resourceAccountsHybridCloudEnvironment() *schema.Resource {
 		ReadContext:   resourceHCEnvRead,
 		UpdateContext: resourceHCEnvUpdate,
 		DeleteContext: resourceHCEnvDelete,
-		Schema:        accountsHybridCloudEnvironmentSchema(),
+		Schema:        accountsHybridCloudEnvironmentSchema(false),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(hcEnvReadyTimeout),
+			Update: schema.DefaultTimeout(hcEnvReadyTimeout),
+			Delete: schema.DefaultTimeout(hcEnvDeleteTimeout),
+		},
 
-		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
-			// If version changed, mark bootstrap_commands as changing to a computed value
-			if d.HasChange(hcEnvBootstrapCommandsVersionFieldName) {
-				if err := d.SetNewComputed(hcEnvBootstrapCommandsFieldName); err != nil {
-					return err
+		CustomizeDiff: customdiff.All(
+			validateHybridCloudEnvironmentPolicy,
+			planHCEnvWaitForReady,
+			planHCEnvCACertificates,
+			func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+				// If version changed (or a field rendered into the commands changed and regenerate_on_change is set),
+				// mark bootstrap_commands (and the parsed bootstrap) as changing to a computed value
+				if d.HasChange(hcEnvBootstrapCommandsVersionFieldName) || hcEnvBootstrapRegenerationRequired(d) {
+					for _, k := range []string{hcEnvBootstrapCommandsFieldName, hcEnvBootstrapFieldName} {
+						if err := d.SetNewComputed(k); err != nil {
+							return err
+						}
+					}
+					return nil
 				}
-				return nil
-			}
 
-			// If version did NOT change, suppress any spurious diff on bootstrap_commands
-			if d.HasChange(hcEnvBootstrapCommandsFieldName) {
-				old, _ := d.GetChange(hcEnvBootstrapCommandsFieldName)
-				if err := d.SetNew(hcEnvBootstrapCommandsFieldName, old); err != nil {
-					return err
+				// If version did NOT change, suppress any spurious diff on bootstrap_commands (and the parsed bootstrap)
+				for _, k := range []string{hcEnvBootstrapCommandsFieldName, hcEnvBootstrapFieldName} {
+					if d.HasChange(k) {
+						old, _ := d.GetChange(k)
+						if err := d.SetNew(k, old); err != nil {
+							return err
+						}
+					}
 				}
-			}
 
-			return nil
-		},
+				return nil
+			},
+		),
 	}
 }
 
@@ -112,6 +130,11 @@ func resourceHCEnvCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 	}
 
+	// Wait until the environment is ready (if requested). A timeout is an error, which taints the created environment.
+	if ds := waitForHCEnvReadyIfRequested(ctx, client, clientCtx, d, m, schema.TimeoutCreate, errorPrefix); ds.HasError() {
+		return ds
+	}
+
 	return resourceHCEnvRead(ctx, d, m)
 }
 
@@ -174,6 +197,18 @@ func resourceHCEnvRead(ctx context.Context, d *schema.ResourceData, m interface{
 	if _, ok := d.GetOk(hcEnvBootstrapCommandsFieldName); !ok {
 		_ = d.Set(hcEnvBootstrapCommandsFieldName, []interface{}{})
 	}
+	if _, ok := d.GetOk(hcEnvBootstrapFieldName); !ok {
+		_ = d.Set(hcEnvBootstrapFieldName, []interface{}{})
+	}
+	if _, ok := d.GetOk(hcEnvWaitForReadyFieldName); !ok {
+		_ = d.Set(hcEnvWaitForReadyFieldName, false)
+	}
+	if _, ok := d.GetOk(hcEnvRegenerateOnChangeFieldName); !ok {
+		_ = d.Set(hcEnvRegenerateOnChangeFieldName, false)
+	}
+	if _, ok := d.GetOk(hcEnvForceDestroyFieldName); !ok {
+		_ = d.Set(hcEnvForceDestroyFieldName, false)
+	}
 
 	return nil
 }
@@ -187,9 +222,12 @@ func resourceHCEnvUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	errorPrefix := "error updating hybrid cloud environment"
 	changedConfigOrName := d.HasChange(hcEnvNameFieldName) || d.HasChange(hcEnvConfigurationFieldName)
 	changedVersion := d.HasChange(hcEnvBootstrapCommandsVersionFieldName)
+	regenerate := changedVersion || hcEnvBootstrapRegenerationRequired(d)
+
+	waitForReady := d.Get(hcEnvWaitForReadyFieldName).(bool)
 
 	// Nothing changed: just refresh (doesn't touch bootstrap fields)
-	if !changedConfigOrName && !changedVersion {
+	if !changedConfigOrName && !changedVersion && !waitForReady {
 		return resourceHCEnvRead(ctx, d, m)
 	}
 
@@ -220,8 +258,8 @@ func resourceHCEnvUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 	}
 
-	// 2) If version changed, rotate/clear explicitly — this is the ONLY place we generate.
-	if changedVersion {
+	// 2) If version changed (or regenerate_on_change applies), rotate/clear explicitly — this is the ONLY place we generate.
+	if regenerate {
 		newV := d.Get(hcEnvBootstrapCommandsVersionFieldName).(int)
 		switch {
 		case newV > 0:
@@ -232,10 +270,16 @@ func resourceHCEnvUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 		default: // newV == 0 or -1
 			// Clear in state
 			_ = d.Set(hcEnvBootstrapCommandsFieldName, []interface{}{})
+			_ = d.Set(hcEnvBootstrapFieldName, []interface{}{})
 		}
 	}
 
-	// 3) Final refresh of server-side fields (won’t mutate bootstrap fields)
+	// 3) Wait until the environment is ready (if requested)
+	if ds := waitForHCEnvReadyIfRequested(ctx, client, clientCtx, d, m, schema.TimeoutUpdate, errorPrefix); ds.HasError() {
+		return ds
+	}
+
+	// 4) Final refresh of server-side fields (won’t mutate bootstrap fields)
 	return resourceHCEnvRead(ctx, d, m)
 }
 
@@ -255,6 +299,20 @@ func resourceHCEnvDelete(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 	}
+	// Refuse to delete an environment which still hosts clusters (or delete them if force_destroy is set)
+	clusterClient, clusterClientCtx, diags := getServiceClient(ctx, m, qcCluster.NewClusterServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	backupClient, _, diags := getServiceClient(ctx, m, qcb.NewBackupServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	diags = guardHCEnvClusters(ctx, clusterClient, backupClient, clusterClientCtx, accountUUID.String(), d.Id(),
+		d.Get(hcEnvForceDestroyFieldName).(bool), d.Timeout(schema.TimeoutDelete))
+	if diags.HasError() {
+		return diags
+	}
 	// Delete the hybrid cloud environment
 	var trailer metadata.MD
 	_, err = client.DeleteHybridCloudEnvironment(
@@ -271,13 +329,13 @@ func resourceHCEnvDelete(ctx context.Context, d *schema.ResourceData, m interfac
 	if err != nil {
 		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
 			d.SetId("")
-			return nil
+			return diags
 		}
-		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		return append(diags, diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))...)
 	}
 	// Resource gone in the backend, clear state
 	d.SetId("")
-	return nil
+	return diags
 }
 
 // setHCEnvBootstrapCommands performs operation to fetch the bootstrap commands for a hybrid cloud environment.
@@ -331,5 +389,41 @@ func setHCEnvBootstrapCommands(
 	if err := d.Set(hcEnvBootstrapCommandsFieldName, values); err != nil {
 		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
 	}
+	// Parse the commands into structured resources, the raw commands remain usable if they cannot be parsed.
+	bootstrap, err := parseHCEnvBootstrapCommands(cmds)
+	if err != nil {
+		_ = d.Set(hcEnvBootstrapFieldName, []interface{}{})
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Bootstrap commands could not be parsed",
+			Detail:   fmt.Sprintf("The bootstrap attribute is empty, use bootstrap_commands instead: %v", err),
+		}}
+	}
+	if err := d.Set(hcEnvBootstrapFieldName, flattenHCEnvBootstrap(bootstrap)); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
 	return nil
 }
+
+// waitForHCEnvReadyIfRequested waits until the hybrid cloud environment is ready, if wait_for_ready is set.
+// timeoutKey: The timeout of the operation (create or update) to use.
+// Returns diagnostic information containing the components which aren't ready, if the wait times out.
+func waitForHCEnvReadyIfRequested(
+	ctx context.Context,
+	client qch.HybridCloudServiceClient,
+	clientCtx context.Context,
+	d *schema.ResourceData,
+	m interface{},
+	timeoutKey string,
+	errorPrefix string,
+) diag.Diagnostics {
+	if !d.Get(hcEnvWaitForReadyFieldName).(bool) {
+		return nil
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	_, err = waitForHCEnvReady(ctx, client, clientCtx, accountUUID.String(), d.Id(), d.Timeout(timeoutKey))
+	return hcEnvReadyDiagnostics(err, errorPrefix)
+}
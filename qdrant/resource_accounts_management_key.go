@@ -0,0 +1,196 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	authv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/auth/v1"
+)
+
+// resourceAccountsManagementKey constructs a Terraform resource for managing a management key,
+// used to authenticate against the Qdrant Cloud API (e.g. by this provider in CI pipelines).
+// Returns a schema.Resource pointer configured with schema definitions and the CRUD functions.
+func resourceAccountsManagementKey() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Account Management Key Resource",
+		ReadContext:   resourceManagementKeyRead,
+		CreateContext: resourceManagementKeyCreate,
+		UpdateContext: nil, // Not available in the public API
+		DeleteContext: resourceManagementKeyDelete,
+		Schema:        accountsManagementKeySchema(),
+		CustomizeDiff: validateManagementKeyRotateAfter,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// validateManagementKeyRotateAfter ensures a new rotation time of the management key isn't in the past.
+// Only checked when it changes (including when the key is replaced), as it passes eventually by design.
+func validateManagementKeyRotateAfter(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChange(managementKeyRotateAfterFieldName) || !d.NewValueKnown(managementKeyRotateAfterFieldName) {
+		return nil
+	}
+	rotateAfter, _ := d.Get(managementKeyRotateAfterFieldName).(string)
+	if managementKeyRotationDue(rotateAfter, time.Now()) {
+		return fmt.Errorf("%s (%s) must be in the future", managementKeyRotateAfterFieldName, rotateAfter)
+	}
+	return nil
+}
+
+// resourceManagementKeyRead performs a read operation to fetch a specific management key.
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+func resourceManagementKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error getting management key"
+	client, clientCtx, diags := getServiceClient(ctx, m, authv1.NewAuthServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	keyID := d.Id()
+
+	var trailer metadata.MD
+	resp, err := client.ListManagementKeys(clientCtx, &authv1.ListManagementKeysRequest{
+		AccountId: accountUUID.String(),
+	}, grpc.Trailer(&trailer))
+	errorPrefix += getRequestID(trailer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+	// Process the correct one, if any
+	for _, key := range resp.GetItems() {
+		if key.GetId() == keyID {
+			for k, v := range flattenManagementKey(key, false) {
+				if err := d.Set(k, v); err != nil {
+					return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+				}
+			}
+			return setManagementKeyRotationDue(d, time.Now())
+		}
+	}
+	// If the key is not found, it might have been deleted manually.
+	// Remove it from the state.
+	d.SetId("")
+	return nil
+}
+
+// setManagementKeyRotationDue sets the rotation_due field of the management key, and returns a warning if it is due.
+func setManagementKeyRotationDue(d *schema.ResourceData, now time.Time) diag.Diagnostics {
+	rotateAfter, _ := d.Get(managementKeyRotateAfterFieldName).(string)
+	due := managementKeyRotationDue(rotateAfter, now)
+	if err := d.Set(managementKeyRotationDueFieldName, due); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting %s: %w", managementKeyRotationDueFieldName, err))
+	}
+	if !due {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Management key rotation due",
+		Detail: fmt.Sprintf("Management key %s (prefix %q) should have been rotated at %s, it is still valid in Qdrant Cloud. Change %s to rotate it.",
+			d.Id(), d.Get(managementKeyPrefixFieldName).(string), rotateAfter, managementKeyRotateAfterFieldName),
+	}}
+}
+
+// resourceManagementKeyCreate performs a create operation to generate a new management key.
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+// Returns diagnostic information encapsulating any runtime issues encountered during the API call.
+func resourceManagementKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error creating management key"
+	client, clientCtx, diags := getServiceClient(ctx, m, authv1.NewAuthServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+
+	var trailer metadata.MD
+	resp, err := client.CreateManagementKey(clientCtx, &authv1.CreateManagementKeyRequest{
+		ManagementKey: &authv1.ManagementKey{
+			AccountId: accountUUID.String(),
+		},
+	}, grpc.Trailer(&trailer))
+	reqID := getRequestID(trailer)
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return diag.Errorf("Invalid argument for management key creation%s: %s", reqID, st.Message())
+		}
+		return diag.FromErr(fmt.Errorf("%s%s: %w", errorPrefix, reqID, err))
+	}
+
+	for k, v := range flattenManagementKey(resp.GetManagementKey(), true) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+		}
+	}
+	if err := d.Set(managementKeyRotationDueFieldName, false); err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+
+	d.SetId(resp.GetManagementKey().GetId())
+	return nil
+}
+
+// resourceManagementKeyDelete performs a delete operation to remove a management key.
+// Refuses to delete the management key used by the provider itself, as it would lock out the provider.
+// ctx: Context to carry deadlines, cancellation signals, and other request-scoped values across API calls.
+// d: Resource data which is used to manage the state of the resource.
+// m: The interface where the configured client is passed.
+func resourceManagementKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	errorPrefix := "error deleting management key"
+	if isProviderManagementKey(d.Get(managementKeyPrefixFieldName).(string), m) {
+		return diag.Errorf("%s %s: it is the management key used by the provider, which would no longer be able to authenticate", errorPrefix, d.Id())
+	}
+	client, clientCtx, diags := getServiceClient(ctx, m, authv1.NewAuthServiceClient)
+	if diags.HasError() {
+		return diags
+	}
+	accountUUID, err := getAccountUUID(d, m)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%s: %w", errorPrefix, err))
+	}
+
+	var trailer metadata.MD
+	_, err = client.DeleteManagementKey(clientCtx, &authv1.DeleteManagementKeyRequest{
+		AccountId:       accountUUID.String(),
+		ManagementKeyId: d.Id(),
+	}, grpc.Trailer(&trailer))
+	reqID := getRequestID(trailer)
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("%s%s: %w", errorPrefix, reqID, err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// isProviderManagementKey returns true if the management key with the provided prefix is the key used by the provider.
+func isProviderManagementKey(prefix string, m interface{}) bool {
+	config, ok := m.(*ProviderConfig)
+	if !ok || prefix == "" {
+		return false
+	}
+	return strings.HasPrefix(config.ApiKey, prefix)
+}
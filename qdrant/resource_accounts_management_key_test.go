@@ -0,0 +1,119 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccResourceAccountsManagementKey(t *testing.T) {
+	apiKey := os.Getenv("QDRANT_CLOUD_API_KEY")
+	accountID := os.Getenv("QDRANT_CLOUD_ACCOUNT_ID")
+
+	config := fmt.Sprintf(`
+provider "qdrant-cloud" {
+  api_key    = "%s"
+  account_id = "%s"
+}
+
+resource "qdrant-cloud_accounts_management_key" "test" {
+  rotate_after = "%s"
+}
+	`, apiKey, accountID, time.Now().Add(24*time.Hour).UTC().Format(time.RFC3339))
+
+	check := resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttrSet("qdrant-cloud_accounts_management_key.test", "id"),
+		resource.TestCheckResourceAttrSet("qdrant-cloud_accounts_management_key.test", "key"),
+		resource.TestCheckResourceAttrSet("qdrant-cloud_accounts_management_key.test", "prefix"),
+		resource.TestCheckResourceAttr("qdrant-cloud_accounts_management_key.test", "account_id", accountID),
+		resource.TestCheckResourceAttr("qdrant-cloud_accounts_management_key.test", "rotation_due", "false"),
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{Config: config, Check: check},
+		},
+	})
+}
+
+func TestSetManagementKeyRotationDue(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newData := func(rotateAfter string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, accountsManagementKeySchema(), map[string]interface{}{
+			managementKeyRotateAfterFieldName: rotateAfter,
+		})
+		d.SetId("key-id-1")
+		require.NoError(t, d.Set(managementKeyPrefixFieldName, "abc12"))
+		return d
+	}
+
+	d := newData("2026-02-01T00:00:00Z")
+	assert.Empty(t, setManagementKeyRotationDue(d, now))
+	assert.Equal(t, false, d.Get(managementKeyRotationDueFieldName))
+
+	d = newData("2025-12-01T00:00:00Z")
+	diags := setManagementKeyRotationDue(d, now)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, `prefix "abc12"`)
+	assert.Equal(t, true, d.Get(managementKeyRotationDueFieldName))
+}
+
+func TestValidateManagementKeyRotateAfter(t *testing.T) {
+	r := resourceAccountsManagementKey()
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	existing := &terraform.InstanceState{
+		ID:         "key-id-1",
+		Attributes: map[string]string{"id": "key-id-1", managementKeyRotateAfterFieldName: past},
+	}
+
+	for name, tc := range map[string]struct {
+		state       *terraform.InstanceState
+		rotateAfter string
+		wantErr     bool
+	}{
+		"new key in the future":         {state: nil, rotateAfter: future},
+		"new key in the past":           {state: nil, rotateAfter: past, wantErr: true},
+		"unchanged after it passed":     {state: existing, rotateAfter: past},
+		"replacement in the future":     {state: existing, rotateAfter: future},
+		"replacement still in the past": {state: existing, rotateAfter: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339), wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := r.Diff(context.Background(), tc.state, terraform.NewResourceConfigRaw(map[string]interface{}{
+				managementKeyRotateAfterFieldName: tc.rotateAfter,
+			}), nil)
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "must be in the future")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIsProviderManagementKey(t *testing.T) {
+	m := &ProviderConfig{ApiKey: "abc12-secret"}
+	assert.True(t, isProviderManagementKey("abc12", m))
+	assert.False(t, isProviderManagementKey("xyz98", m))
+	assert.False(t, isProviderManagementKey("", m))
+	assert.False(t, isProviderManagementKey("abc12", nil))
+}
+
+func TestManagementKeyResource(t *testing.T) {
+	assert.Contains(t, Provider().ResourcesMap, "qdrant-cloud_accounts_management_key")
+	r := resourceAccountsManagementKey()
+	assert.Nil(t, r.UpdateContext)
+	assert.NotNil(t, r.Importer)
+}
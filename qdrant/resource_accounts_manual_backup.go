@@ -3,17 +3,26 @@ package qdrant
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	qcb "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
 )
 
+const (
+	backupWaitPending      = "waiting"
+	backupWaitSucceeded    = "succeeded"
+	backupWaitPollInterval = 10 * time.Second
+)
+
 // resourceAccountsManualBackup constructs a Terraform resource for managing a one-off
 // cluster backup associated with an account. Returns a schema.Resource configured with
 // schema definitions and CRUD functions.
@@ -174,3 +183,76 @@ func resourceBackupDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	d.SetId("")
 	return nil
 }
+
+// createBackupAndWait triggers a manual backup of the provided cluster and waits until it succeeded.
+// client: The backup service client to use.
+// clientCtx: Context holding the authentication metadata.
+// retentionPeriod: Optional retention period of the backup (nil keeps it until deleted).
+// timeout: Maximum time to wait for the backup to succeed.
+// Returns the succeeded backup or an error if the backup could not be created, failed or timed out.
+func createBackupAndWait(
+	ctx context.Context,
+	client qcb.BackupServiceClient,
+	clientCtx context.Context,
+	accountID, clusterID string,
+	retentionPeriod *durationpb.Duration,
+	timeout time.Duration,
+) (*qcb.Backup, error) {
+	var trailer metadata.MD
+	resp, err := client.CreateBackup(clientCtx, &qcb.CreateBackupRequest{
+		Backup: &qcb.Backup{
+			AccountId:       accountID,
+			ClusterId:       clusterID,
+			RetentionPeriod: retentionPeriod,
+		},
+	}, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, fmt.Errorf("creating backup%s: %w", getRequestID(trailer), err)
+	}
+	backupID := resp.GetBackup().GetId()
+
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{
+			backupWaitPending,
+		},
+		Target: []string{
+			backupWaitSucceeded,
+		},
+		Refresh:      backupStatusRefreshFunc(client, clientCtx, accountID, backupID),
+		Timeout:      timeout,
+		PollInterval: backupWaitPollInterval,
+	}
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for backup %s: %w", backupID, err)
+	}
+	return result.(*qcb.Backup), nil
+}
+
+// backupStatusRefreshFunc returns a StateRefreshFunc that polls GetBackup
+// until the backup succeeded or failed.
+func backupStatusRefreshFunc(
+	client qcb.BackupServiceClient,
+	ctx context.Context,
+	accountID, backupID string,
+) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.GetBackup(ctx, &qcb.GetBackupRequest{
+			AccountId: accountID,
+			BackupId:  backupID,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		backup := resp.GetBackup()
+		switch backup.GetStatus() {
+		case qcb.BackupStatus_BACKUP_STATUS_SUCCEEDED:
+			return backup, backupWaitSucceeded, nil
+		case qcb.BackupStatus_BACKUP_STATUS_FAILED:
+			return nil, "", fmt.Errorf("backup %s failed (status=%q)", backupID, backup.GetStatus().String())
+		}
+
+		return backup, backupWaitPending, nil
+	}
+}
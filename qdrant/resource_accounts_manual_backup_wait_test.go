@@ -0,0 +1,116 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	qcb "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+)
+
+type mockBackupServiceClient struct {
+	qcb.BackupServiceClient
+	created   *qcb.Backup
+	statuses  []qcb.BackupStatus
+	getErr    error
+	callCount int
+}
+
+func (m *mockBackupServiceClient) CreateBackup(_ context.Context, in *qcb.CreateBackupRequest, _ ...grpc.CallOption) (*qcb.CreateBackupResponse, error) {
+	m.created = in.GetBackup()
+	return &qcb.CreateBackupResponse{Backup: &qcb.Backup{
+		Id:              "backup-1",
+		AccountId:       in.GetBackup().GetAccountId(),
+		ClusterId:       in.GetBackup().GetClusterId(),
+		RetentionPeriod: in.GetBackup().GetRetentionPeriod(),
+	}}, nil
+}
+
+func (m *mockBackupServiceClient) GetBackup(_ context.Context, in *qcb.GetBackupRequest, _ ...grpc.CallOption) (*qcb.GetBackupResponse, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	idx := m.callCount
+	m.callCount++
+	if idx >= len(m.statuses) {
+		idx = len(m.statuses) - 1
+	}
+	return &qcb.GetBackupResponse{Backup: &qcb.Backup{
+		Id:     in.GetBackupId(),
+		Status: m.statuses[idx],
+	}}, nil
+}
+
+func TestBackupStatusRefresh_PendingUntilSucceeded(t *testing.T) {
+	mock := &mockBackupServiceClient{
+		statuses: []qcb.BackupStatus{
+			qcb.BackupStatus_BACKUP_STATUS_UNSPECIFIED,
+			qcb.BackupStatus_BACKUP_STATUS_SUCCEEDED,
+		},
+	}
+	refreshFunc := backupStatusRefreshFunc(mock, context.Background(), "account-1", "backup-1")
+
+	_, state, err := refreshFunc()
+	require.NoError(t, err)
+	assert.Equal(t, backupWaitPending, state)
+
+	result, state, err := refreshFunc()
+	require.NoError(t, err)
+	assert.Equal(t, backupWaitSucceeded, state)
+	assert.Equal(t, "backup-1", result.(*qcb.Backup).GetId())
+}
+
+func TestBackupStatusRefresh_ReturnsErrorOnFailure(t *testing.T) {
+	mock := &mockBackupServiceClient{
+		statuses: []qcb.BackupStatus{qcb.BackupStatus_BACKUP_STATUS_FAILED},
+	}
+
+	result, state, err := backupStatusRefreshFunc(mock, context.Background(), "account-1", "backup-1")()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BACKUP_STATUS_FAILED")
+	assert.Nil(t, result)
+	assert.Empty(t, state)
+}
+
+func TestBackupStatusRefresh_ReturnsAPIError(t *testing.T) {
+	mock := &mockBackupServiceClient{getErr: errors.New("connection refused")}
+
+	_, _, err := backupStatusRefreshFunc(mock, context.Background(), "account-1", "backup-1")()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestCreateBackupAndWait(t *testing.T) {
+	mock := &mockBackupServiceClient{
+		statuses: []qcb.BackupStatus{qcb.BackupStatus_BACKUP_STATUS_SUCCEEDED},
+	}
+	retention := durationpb.New(72 * time.Hour)
+
+	backup, err := createBackupAndWait(context.Background(), mock, context.Background(), "account-1", "cluster-1", retention, time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, "backup-1", backup.GetId())
+	require.NotNil(t, mock.created)
+	assert.Equal(t, "account-1", mock.created.GetAccountId())
+	assert.Equal(t, "cluster-1", mock.created.GetClusterId())
+	assert.Equal(t, retention, mock.created.GetRetentionPeriod())
+}
+
+func TestCreateBackupAndWait_Failed(t *testing.T) {
+	mock := &mockBackupServiceClient{
+		statuses: []qcb.BackupStatus{qcb.BackupStatus_BACKUP_STATUS_FAILED},
+	}
+
+	_, err := createBackupAndWait(context.Background(), mock, context.Background(), "account-1", "cluster-1", nil, time.Minute)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "backup-1")
+}
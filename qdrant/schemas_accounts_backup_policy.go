@@ -0,0 +1,401 @@
+package qdrant
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	backupv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+)
+
+const (
+	backupPolicyFieldTemplate              = "Backup Policy Schema %s field"
+	backupPolicyAccountIDFieldName         = "account_id"
+	backupPolicyClusterIDFieldName         = "cluster_id"
+	backupPolicyHourFieldName              = "hour"
+	backupPolicyMinuteFieldName            = "minute"
+	backupPolicyDailyFieldName             = "daily"
+	backupPolicyWeeklyFieldName            = "weekly"
+	backupPolicyMonthlyFieldName           = "monthly"
+	backupPolicyRetentionPeriodFieldName   = "retention_period"
+	backupPolicyDayOfWeekFieldName         = "day_of_week"
+	backupPolicyDayOfMonthFieldName        = "day_of_month"
+	backupPolicyDeleteBackupsOnDestroy     = "delete_backups_on_destroy"
+	backupPolicySchedulesFieldName         = "schedules"
+	backupPolicyScheduleTierFieldName      = "tier"
+	backupPolicyScheduleIDFieldName        = "id"
+	backupPolicyScheduleCronFieldName      = "cron_expression"
+	backupPolicyScheduleRetentionFieldName = "retention_period"
+	backupPolicyScheduleStatusFieldName    = "status"
+)
+
+// backupPolicyTiers contains the supported tiers of a backup policy, in the order they are reconciled.
+var backupPolicyTiers = []string{
+	backupPolicyDailyFieldName,
+	backupPolicyWeeklyFieldName,
+	backupPolicyMonthlyFieldName,
+}
+
+// backupPolicyTierStagger is the offset between the tiers of a backup policy: the daily tier runs at the configured time,
+// the weekly and monthly tiers 1 and 2 staggers later, so backups of different tiers never start at the same time.
+const backupPolicyTierStagger = 60 // minutes
+
+// backupPolicySchedule is a single backup schedule managed by a backup policy.
+type backupPolicySchedule struct {
+	Tier            string
+	ID              string
+	CronExpression  string
+	RetentionPeriod string
+	Status          string
+}
+
+// accountsBackupPolicySchema defines the schema for a backup policy resource.
+func accountsBackupPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		backupPolicyAccountIDFieldName: {
+			Description: fmt.Sprintf(backupPolicyFieldTemplate, "Account ID"),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		backupPolicyClusterIDFieldName: {
+			Description: fmt.Sprintf(backupPolicyFieldTemplate, "Cluster ID"),
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+		},
+		backupPolicyHourFieldName: {
+			Description:      fmt.Sprintf(backupPolicyFieldTemplate, "Hour of the day (UTC) at which the daily tier runs, the weekly and monthly tiers run 1 and 2 hours later (wrapping around midnight on the same day)"),
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          0,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 23)),
+		},
+		backupPolicyMinuteFieldName: {
+			Description:      fmt.Sprintf(backupPolicyFieldTemplate, "Minute of the hour at which the daily tier runs (the weekly and monthly tiers are staggered by 1 and 2 hours)"),
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          0,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 59)),
+		},
+		backupPolicyDailyFieldName: {
+			Description: fmt.Sprintf(backupPolicyFieldTemplate, "Daily backups"),
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: backupPolicyTierSchema(nil),
+			},
+			AtLeastOneOf: backupPolicyTiers,
+		},
+		backupPolicyWeeklyFieldName: {
+			Description: fmt.Sprintf(backupPolicyFieldTemplate, "Weekly backups"),
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: backupPolicyTierSchema(map[string]*schema.Schema{
+					backupPolicyDayOfWeekFieldName: {
+						Description:      "Day of the week at which the weekly backup runs (0 = Sunday, 6 = Saturday).",
+						Type:             schema.TypeInt,
+						Optional:         true,
+						Default:          0,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 6)),
+					},
+				}),
+			},
+			AtLeastOneOf: backupPolicyTiers,
+		},
+		backupPolicyMonthlyFieldName: {
+			Description: fmt.Sprintf(backupPolicyFieldTemplate, "Monthly backups"),
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: backupPolicyTierSchema(map[string]*schema.Schema{
+					backupPolicyDayOfMonthFieldName: {
+						Description:      "Day of the month at which the monthly backup runs (1-28, so it runs every month).",
+						Type:             schema.TypeInt,
+						Optional:         true,
+						Default:          1,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 28)),
+					},
+				}),
+			},
+			AtLeastOneOf: backupPolicyTiers,
+		},
+		backupPolicyDeleteBackupsOnDestroy: {
+			Description: "Whether to delete the backups of a tier when it (or the policy) is destroyed.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		backupPolicySchedulesFieldName: {
+			Description: fmt.Sprintf(backupPolicyFieldTemplate, "Backup schedules managed by the policy"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					backupPolicyScheduleTierFieldName: {
+						Description: "Tier of the schedule (daily, weekly or monthly).",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					backupPolicyScheduleIDFieldName: {
+						Description: "Identifier of the backup schedule.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					backupPolicyScheduleCronFieldName: {
+						Description: "Cron expression of the backup schedule.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					backupPolicyScheduleRetentionFieldName: {
+						Description: "Retention period of the backup schedule.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					backupPolicyScheduleStatusFieldName: {
+						Description: "Status of the backup schedule.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// backupPolicyTierSchema returns the schema of a single tier, extended with the provided fields.
+func backupPolicyTierSchema(extra map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		backupPolicyRetentionPeriodFieldName: {
+			Description:      `Retention period of the backups of this tier as a Go duration string (e.g., "168h").`,
+			Type:             schema.TypeString,
+			Required:         true,
+			DiffSuppressFunc: suppressDurationDiff,
+			ValidateDiagFunc: validation.ToDiagFunc(validateDuration),
+		},
+	}
+	for k, v := range extra {
+		s[k] = v
+	}
+	return s
+}
+
+// backupPolicyGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type backupPolicyGetter interface {
+	Get(key string) interface{}
+}
+
+// backupPolicyTierOffset returns the offset (in minutes) of the provided tier from the time of the backup policy.
+func backupPolicyTierOffset(tier string) int {
+	for i, t := range backupPolicyTiers {
+		if t == tier {
+			return i * backupPolicyTierStagger
+		}
+	}
+	return 0
+}
+
+// backupPolicyTierTime returns the hour and minute at which the provided tier runs, given the time of the backup policy.
+// The offset wraps around midnight, the tier still runs on its configured day.
+func backupPolicyTierTime(tier string, hour, minute int) (int, int) {
+	at := (hour*60 + minute + backupPolicyTierOffset(tier)) % (24 * 60)
+	return at / 60, at % 60
+}
+
+// backupPolicyTimeOfTier returns the time of the backup policy (hour and minute), given the time at which the provided tier runs.
+// This is the inverse of backupPolicyTierTime.
+func backupPolicyTimeOfTier(tier string, hour, minute int) (int, int) {
+	at := (hour*60 + minute - backupPolicyTierOffset(tier) + 24*60) % (24 * 60)
+	return at / 60, at % 60
+}
+
+// expandBackupPolicySchedules returns the desired schedules (keyed by tier) of the provided backup policy configuration.
+func expandBackupPolicySchedules(d backupPolicyGetter) map[string]backupPolicySchedule {
+	hour := d.Get(backupPolicyHourFieldName).(int)
+	minute := d.Get(backupPolicyMinuteFieldName).(int)
+	result := map[string]backupPolicySchedule{}
+	for _, tier := range backupPolicyTiers {
+		items := d.Get(tier).([]interface{})
+		if len(items) == 0 || items[0] == nil {
+			continue
+		}
+		item := items[0].(map[string]interface{})
+		tierHour, tierMinute := backupPolicyTierTime(tier, hour, minute)
+		var cron string
+		switch tier {
+		case backupPolicyDailyFieldName:
+			cron = fmt.Sprintf("%d %d * * *", tierMinute, tierHour)
+		case backupPolicyWeeklyFieldName:
+			cron = fmt.Sprintf("%d %d * * %d", tierMinute, tierHour, item[backupPolicyDayOfWeekFieldName].(int))
+		case backupPolicyMonthlyFieldName:
+			cron = fmt.Sprintf("%d %d %d * *", tierMinute, tierHour, item[backupPolicyDayOfMonthFieldName].(int))
+		}
+		result[tier] = backupPolicySchedule{
+			Tier:            tier,
+			CronExpression:  cron,
+			RetentionPeriod: formatDuration(parseDuration(item[backupPolicyRetentionPeriodFieldName].(string))),
+		}
+	}
+	return result
+}
+
+// readBackupPolicySchedules returns the schedules (keyed by tier) stored in the state of a backup policy.
+func readBackupPolicySchedules(d backupPolicyGetter) map[string]backupPolicySchedule {
+	result := map[string]backupPolicySchedule{}
+	for _, item := range d.Get(backupPolicySchedulesFieldName).([]interface{}) {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		schedule := backupPolicySchedule{
+			Tier:            m[backupPolicyScheduleTierFieldName].(string),
+			ID:              m[backupPolicyScheduleIDFieldName].(string),
+			CronExpression:  m[backupPolicyScheduleCronFieldName].(string),
+			RetentionPeriod: m[backupPolicyScheduleRetentionFieldName].(string),
+			Status:          m[backupPolicyScheduleStatusFieldName].(string),
+		}
+		result[schedule.Tier] = schedule
+	}
+	return result
+}
+
+// backupPolicySchedulesInSync returns true if the current schedules match the desired ones (ignoring IDs and status).
+func backupPolicySchedulesInSync(desired, current map[string]backupPolicySchedule) bool {
+	if len(desired) != len(current) {
+		return false
+	}
+	for tier, want := range desired {
+		have, ok := current[tier]
+		if !ok || have.CronExpression != want.CronExpression || have.RetentionPeriod != want.RetentionPeriod {
+			return false
+		}
+	}
+	return true
+}
+
+// newBackupPolicySchedule converts the provided API backup schedule into a schedule of the given tier.
+func newBackupPolicySchedule(tier string, schedule *backupv1.BackupSchedule) backupPolicySchedule {
+	return backupPolicySchedule{
+		Tier:            tier,
+		ID:              schedule.GetId(),
+		CronExpression:  schedule.GetSchedule(),
+		RetentionPeriod: formatDuration(schedule.GetRetentionPeriod()),
+		Status:          schedule.GetStatus().String(),
+	}
+}
+
+// flattenBackupPolicySchedules converts the provided schedules into the Terraform representation (ordered by tier).
+func flattenBackupPolicySchedules(schedules map[string]backupPolicySchedule) []interface{} {
+	tiers := make([]string, 0, len(schedules))
+	for tier := range schedules {
+		tiers = append(tiers, tier)
+	}
+	order := map[string]int{}
+	for i, tier := range backupPolicyTiers {
+		order[tier] = i
+	}
+	sort.Slice(tiers, func(i, j int) bool { return order[tiers[i]] < order[tiers[j]] })
+
+	result := make([]interface{}, 0, len(tiers))
+	for _, tier := range tiers {
+		s := schedules[tier]
+		result = append(result, map[string]interface{}{
+			backupPolicyScheduleTierFieldName:      s.Tier,
+			backupPolicyScheduleIDFieldName:        s.ID,
+			backupPolicyScheduleCronFieldName:      s.CronExpression,
+			backupPolicyScheduleRetentionFieldName: s.RetentionPeriod,
+			backupPolicyScheduleStatusFieldName:    s.Status,
+		})
+	}
+	return result
+}
+
+// parseBackupPolicyCron returns the tier, minute, hour and day (day of the week or month, 0 for daily)
+// of a cron expression as created by a backup policy, ok is false if the expression doesn't match any tier.
+func parseBackupPolicyCron(cronExpression string) (tier string, minute, hour, day int, ok bool) {
+	fields := strings.Fields(cronExpression)
+	if len(fields) != 5 || fields[3] != "*" {
+		return "", 0, 0, 0, false
+	}
+	number := func(field string, min, max int) (int, bool) {
+		n, err := strconv.Atoi(field)
+		// Only accept the canonical form (e.g. no leading zeros), as created by the policy.
+		if err != nil || strconv.Itoa(n) != field || n < min || n > max {
+			return 0, false
+		}
+		return n, true
+	}
+	var minuteOK, hourOK, dayOK bool
+	minute, minuteOK = number(fields[0], 0, 59)
+	hour, hourOK = number(fields[1], 0, 23)
+	if !minuteOK || !hourOK {
+		return "", 0, 0, 0, false
+	}
+	switch {
+	case fields[2] == "*" && fields[4] == "*":
+		return backupPolicyDailyFieldName, minute, hour, 0, true
+	case fields[2] == "*":
+		day, dayOK = number(fields[4], 0, 6)
+		tier = backupPolicyWeeklyFieldName
+	case fields[4] == "*":
+		day, dayOK = number(fields[2], 1, 28)
+		tier = backupPolicyMonthlyFieldName
+	}
+	if !dayOK {
+		return "", 0, 0, 0, false
+	}
+	return tier, minute, hour, day, true
+}
+
+// flattenImportedBackupPolicy converts the backup schedules of a cluster into the configuration and state of a backup policy.
+// Schedules which don't match a tier are ignored (they stay unmanaged), an error is returned if no schedule matches,
+// a tier matches multiple schedules or the tiers aren't staggered from the same time (as done by backupPolicyTierTime).
+func flattenImportedBackupPolicy(clusterID string, schedules []*backupv1.BackupSchedule) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	current := map[string]backupPolicySchedule{}
+	var at string
+	for _, schedule := range schedules {
+		tier, minute, hour, day, ok := parseBackupPolicyCron(schedule.GetSchedule())
+		if !ok {
+			continue
+		}
+		if existing, found := current[tier]; found {
+			return nil, fmt.Errorf("cluster %s has multiple %s backup schedules (%s and %s), a backup policy manages a single schedule per tier",
+				clusterID, tier, existing.ID, schedule.GetId())
+		}
+		policyHour, policyMinute := backupPolicyTimeOfTier(tier, hour, minute)
+		if runsAt := fmt.Sprintf("%02d:%02d", policyHour, policyMinute); at == "" {
+			at = runsAt
+			result[backupPolicyHourFieldName] = policyHour
+			result[backupPolicyMinuteFieldName] = policyMinute
+		} else if runsAt != at {
+			return nil, fmt.Errorf("the backup schedules of cluster %s don't match the same policy time (%s and %s UTC), a backup policy runs the weekly and monthly tiers %d and %d minutes after the daily tier",
+				clusterID, at, runsAt, backupPolicyTierOffset(backupPolicyWeeklyFieldName), backupPolicyTierOffset(backupPolicyMonthlyFieldName))
+		}
+		item := map[string]interface{}{
+			backupPolicyRetentionPeriodFieldName: formatDuration(schedule.GetRetentionPeriod()),
+		}
+		switch tier {
+		case backupPolicyWeeklyFieldName:
+			item[backupPolicyDayOfWeekFieldName] = day
+		case backupPolicyMonthlyFieldName:
+			item[backupPolicyDayOfMonthFieldName] = day
+		}
+		result[tier] = []interface{}{item}
+		current[tier] = newBackupPolicySchedule(tier, schedule)
+	}
+	if len(current) == 0 {
+		return nil, fmt.Errorf("cluster %s has no backup schedule matching a daily, weekly or monthly tier of a backup policy", clusterID)
+	}
+	result[backupPolicySchedulesFieldName] = flattenBackupPolicySchedules(current)
+	return result, nil
+}
@@ -0,0 +1,222 @@
+package qdrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	backupv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
+)
+
+func TestExpandBackupPolicySchedules(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, accountsBackupPolicySchema(), map[string]interface{}{
+		backupPolicyClusterIDFieldName: "cluster-id-1",
+		backupPolicyHourFieldName:      3,
+		backupPolicyMinuteFieldName:    30,
+		backupPolicyDailyFieldName: []interface{}{
+			map[string]interface{}{backupPolicyRetentionPeriodFieldName: "168h"},
+		},
+		backupPolicyMonthlyFieldName: []interface{}{
+			map[string]interface{}{
+				backupPolicyRetentionPeriodFieldName: "8760h",
+				backupPolicyDayOfMonthFieldName:      15,
+			},
+		},
+	})
+
+	expected := map[string]backupPolicySchedule{
+		backupPolicyDailyFieldName: {
+			Tier:            backupPolicyDailyFieldName,
+			CronExpression:  "30 3 * * *",
+			RetentionPeriod: "168h0m0s",
+		},
+		backupPolicyMonthlyFieldName: {
+			Tier:            backupPolicyMonthlyFieldName,
+			CronExpression:  "30 5 15 * *",
+			RetentionPeriod: "8760h0m0s",
+		},
+	}
+	assert.Equal(t, expected, expandBackupPolicySchedules(d))
+}
+
+func TestExpandBackupPolicySchedulesWeeklyDefaults(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, accountsBackupPolicySchema(), map[string]interface{}{
+		backupPolicyClusterIDFieldName: "cluster-id-1",
+		backupPolicyWeeklyFieldName: []interface{}{
+			map[string]interface{}{backupPolicyRetentionPeriodFieldName: "720h"},
+		},
+	})
+
+	schedules := expandBackupPolicySchedules(d)
+	assert.Len(t, schedules, 1)
+	assert.Equal(t, "0 1 * * 0", schedules[backupPolicyWeeklyFieldName].CronExpression)
+	assert.Equal(t, "720h0m0s", schedules[backupPolicyWeeklyFieldName].RetentionPeriod)
+}
+
+func TestExpandBackupPolicySchedulesStaggered(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, accountsBackupPolicySchema(), map[string]interface{}{
+		backupPolicyClusterIDFieldName: "cluster-id-1",
+		backupPolicyHourFieldName:      23,
+		backupPolicyMinuteFieldName:    15,
+		backupPolicyDailyFieldName: []interface{}{
+			map[string]interface{}{backupPolicyRetentionPeriodFieldName: "168h"},
+		},
+		backupPolicyWeeklyFieldName: []interface{}{
+			map[string]interface{}{backupPolicyRetentionPeriodFieldName: "720h"},
+		},
+		backupPolicyMonthlyFieldName: []interface{}{
+			map[string]interface{}{backupPolicyRetentionPeriodFieldName: "8760h"},
+		},
+	})
+
+	schedules := expandBackupPolicySchedules(d)
+	// The tiers don't start at the same time, the offsets wrap around midnight
+	assert.Equal(t, "15 23 * * *", schedules[backupPolicyDailyFieldName].CronExpression)
+	assert.Equal(t, "15 0 * * 0", schedules[backupPolicyWeeklyFieldName].CronExpression)
+	assert.Equal(t, "15 1 1 * *", schedules[backupPolicyMonthlyFieldName].CronExpression)
+}
+
+func TestBackupPolicyTierTime(t *testing.T) {
+	for _, tier := range backupPolicyTiers {
+		for _, at := range [][2]int{{0, 0}, {2, 30}, {22, 45}, {23, 59}} {
+			hour, minute := backupPolicyTierTime(tier, at[0], at[1])
+			policyHour, policyMinute := backupPolicyTimeOfTier(tier, hour, minute)
+			assert.Equal(t, at, [2]int{policyHour, policyMinute}, tier)
+		}
+	}
+	hour, minute := backupPolicyTierTime(backupPolicyMonthlyFieldName, 22, 45)
+	assert.Equal(t, [2]int{0, 45}, [2]int{hour, minute})
+}
+
+func TestBackupPolicySchedulesInSync(t *testing.T) {
+	desired := map[string]backupPolicySchedule{
+		backupPolicyDailyFieldName: {Tier: backupPolicyDailyFieldName, CronExpression: "0 0 * * *", RetentionPeriod: "168h0m0s"},
+	}
+	inSync := map[string]backupPolicySchedule{
+		backupPolicyDailyFieldName: {Tier: backupPolicyDailyFieldName, ID: "id-1", CronExpression: "0 0 * * *", RetentionPeriod: "168h0m0s", Status: "ACTIVE"},
+	}
+	assert.True(t, backupPolicySchedulesInSync(desired, inSync))
+
+	changedRetention := map[string]backupPolicySchedule{
+		backupPolicyDailyFieldName: {Tier: backupPolicyDailyFieldName, ID: "id-1", CronExpression: "0 0 * * *", RetentionPeriod: "24h0m0s"},
+	}
+	assert.False(t, backupPolicySchedulesInSync(desired, changedRetention))
+
+	changedCron := map[string]backupPolicySchedule{
+		backupPolicyDailyFieldName: {Tier: backupPolicyDailyFieldName, ID: "id-1", CronExpression: "0 1 * * *", RetentionPeriod: "168h0m0s"},
+	}
+	assert.False(t, backupPolicySchedulesInSync(desired, changedCron))
+
+	extraTier := map[string]backupPolicySchedule{
+		backupPolicyDailyFieldName:  inSync[backupPolicyDailyFieldName],
+		backupPolicyWeeklyFieldName: {Tier: backupPolicyWeeklyFieldName, ID: "id-2", CronExpression: "0 0 * * 0", RetentionPeriod: "720h0m0s"},
+	}
+	assert.False(t, backupPolicySchedulesInSync(desired, extraTier))
+	assert.False(t, backupPolicySchedulesInSync(desired, map[string]backupPolicySchedule{}))
+}
+
+func TestFlattenAndReadBackupPolicySchedules(t *testing.T) {
+	schedules := map[string]backupPolicySchedule{
+		backupPolicyMonthlyFieldName: newBackupPolicySchedule(backupPolicyMonthlyFieldName, &backupv1.BackupSchedule{
+			Id:              "id-3",
+			Schedule:        "0 0 1 * *",
+			RetentionPeriod: durationpb.New(365 * 24 * time.Hour),
+			Status:          backupv1.BackupScheduleStatus_BACKUP_SCHEDULE_STATUS_ACTIVE,
+		}),
+		backupPolicyDailyFieldName: newBackupPolicySchedule(backupPolicyDailyFieldName, &backupv1.BackupSchedule{
+			Id:              "id-1",
+			Schedule:        "0 0 * * *",
+			RetentionPeriod: durationpb.New(7 * 24 * time.Hour),
+			Status:          backupv1.BackupScheduleStatus_BACKUP_SCHEDULE_STATUS_ACTIVE,
+		}),
+	}
+
+	flattened := flattenBackupPolicySchedules(schedules)
+	assert.Len(t, flattened, 2)
+	// Ordered by tier
+	assert.Equal(t, backupPolicyDailyFieldName, flattened[0].(map[string]interface{})[backupPolicyScheduleTierFieldName])
+	assert.Equal(t, backupPolicyMonthlyFieldName, flattened[1].(map[string]interface{})[backupPolicyScheduleTierFieldName])
+	assert.Equal(t, "8760h0m0s", flattened[1].(map[string]interface{})[backupPolicyScheduleRetentionFieldName])
+
+	d := schema.TestResourceDataRaw(t, accountsBackupPolicySchema(), map[string]interface{}{
+		backupPolicyClusterIDFieldName: "cluster-id-1",
+	})
+	assert.NoError(t, d.Set(backupPolicySchedulesFieldName, flattened))
+	assert.Equal(t, schedules, readBackupPolicySchedules(d))
+}
+
+func TestParseBackupPolicyCron(t *testing.T) {
+	tests := []struct {
+		cron              string
+		tier              string
+		minute, hour, day int
+		ok                bool
+	}{
+		{cron: "30 2 * * *", tier: backupPolicyDailyFieldName, minute: 30, hour: 2, ok: true},
+		{cron: "0 2 * * 6", tier: backupPolicyWeeklyFieldName, hour: 2, day: 6, ok: true},
+		{cron: "0 0 28 * *", tier: backupPolicyMonthlyFieldName, day: 28, ok: true},
+		{cron: "0 0 31 * *"},
+		{cron: "0 0 1 * 1"},
+		{cron: "0 0 * 1 *"},
+		{cron: "05 2 * * *"},
+		{cron: "*/5 * * * *"},
+		{cron: "@daily"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cron, func(t *testing.T) {
+			tier, minute, hour, day, ok := parseBackupPolicyCron(tt.cron)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.tier, tier)
+			assert.Equal(t, []int{tt.minute, tt.hour, tt.day}, []int{minute, hour, day})
+		})
+	}
+}
+
+func TestFlattenImportedBackupPolicy(t *testing.T) {
+	schedule := func(id, cron string, retention time.Duration) *backupv1.BackupSchedule {
+		return &backupv1.BackupSchedule{Id: id, Schedule: cron, RetentionPeriod: durationpb.New(retention)}
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		imported, err := flattenImportedBackupPolicy("cluster-id-1", []*backupv1.BackupSchedule{
+			schedule("id-1", "15 3 * * *", 7*24*time.Hour),
+			schedule("id-2", "*/5 * * * *", time.Hour),       // Not managed by a policy
+			schedule("id-3", "15 5 1 * *", 365*24*time.Hour), // Staggered by 2 hours
+		})
+		require.NoError(t, err)
+		imported[backupPolicyClusterIDFieldName] = "cluster-id-1"
+		d := schema.TestResourceDataRaw(t, accountsBackupPolicySchema(), map[string]interface{}{})
+		for k, v := range imported {
+			require.NoError(t, d.Set(k, v))
+		}
+		assert.Equal(t, 3, d.Get(backupPolicyHourFieldName))
+		assert.Equal(t, 15, d.Get(backupPolicyMinuteFieldName))
+		assert.Equal(t, 1, d.Get("monthly.0.day_of_month"))
+		current := readBackupPolicySchedules(d)
+		assert.Len(t, current, 2)
+		assert.Equal(t, "id-3", current[backupPolicyMonthlyFieldName].ID)
+		assert.True(t, backupPolicySchedulesInSync(expandBackupPolicySchedules(d), current))
+	})
+	t.Run("no matching schedule", func(t *testing.T) {
+		_, err := flattenImportedBackupPolicy("cluster-id-1", []*backupv1.BackupSchedule{schedule("id-1", "@daily", time.Hour)})
+		assert.ErrorContains(t, err, "has no backup schedule matching")
+	})
+	t.Run("multiple schedules per tier", func(t *testing.T) {
+		_, err := flattenImportedBackupPolicy("cluster-id-1", []*backupv1.BackupSchedule{
+			schedule("id-1", "0 2 * * *", time.Hour),
+			schedule("id-2", "0 2 * * *", 2*time.Hour),
+		})
+		assert.ErrorContains(t, err, "multiple daily backup schedules (id-1 and id-2)")
+	})
+	t.Run("not staggered", func(t *testing.T) {
+		_, err := flattenImportedBackupPolicy("cluster-id-1", []*backupv1.BackupSchedule{
+			schedule("id-1", "0 2 * * *", time.Hour),
+			schedule("id-2", "0 2 * * 0", time.Hour),
+		})
+		assert.ErrorContains(t, err, "don't match the same policy time (02:00 and 01:00 UTC)")
+	})
+}
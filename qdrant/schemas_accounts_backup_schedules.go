@@ -1,9 +1,14 @@
 package qdrant
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	backupv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/backup/v1"
 )
@@ -20,6 +25,11 @@ const (
 	backupScheduleDeletedAtFieldName       = "deleted_at"
 	backupScheduleDeleteBackupsOnDestroy   = "delete_backups_on_destroy"
 	backupSchedulesFieldName               = "schedules"
+	backupScheduleNextRunsFieldName        = "next_runs"
+	backupScheduleEnabledFieldName         = "enabled"
+
+	// backupScheduleNextRunsCount is the amount of upcoming runs exposed in next_runs.
+	backupScheduleNextRunsCount = 5
 )
 
 func accountsBackupScheduleResourceSchema(asDataSource bool) map[string]*schema.Schema {
@@ -70,16 +80,38 @@ func accountsBackupScheduleResourceSchema(asDataSource bool) map[string]*schema.
 			Type:        schema.TypeString,
 			Computed:    true,
 		},
+		backupScheduleEnabledFieldName: {
+			Description: fmt.Sprintf(backupScheduleFieldTemplate, "Whether the schedule is active, set to false to pause it without deleting it (or its backups)"),
+			Type:        schema.TypeBool,
+			Optional:    !asDataSource,
+			Computed:    asDataSource,
+		},
 		backupScheduleDeleteBackupsOnDestroy: {
 			Description: "Whether to delete backups when the schedule is destroyed.",
 			Type:        schema.TypeBool,
 			Optional:    true,
 			Default:     true,
 		},
+		backupScheduleNextRunsFieldName: {
+			Description: fmt.Sprintf(backupScheduleFieldTemplate, fmt.Sprintf("Next %d run times (RFC3339, UTC) of the schedule", backupScheduleNextRunsCount)),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 	}
 
 	if !asDataSource {
+		s[backupScheduleEnabledFieldName].Default = true
 		s[backupScheduleRetentionPeriodFieldName].DiffSuppressFunc = suppressDurationDiff
+		s[backupScheduleCronExpressionFieldName].Description = fmt.Sprintf(backupScheduleFieldTemplate,
+			`Cron expression for the schedule, in UTC. Either standard 5-field syntax (minute hour day-of-month month day-of-week)
+or one of the macros @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly.`)
+		s[backupScheduleCronExpressionFieldName].ValidateDiagFunc = validation.ToDiagFunc(validateCronExpression)
+		s[backupScheduleNextRunsFieldName].Description = fmt.Sprintf(backupScheduleFieldTemplate,
+			fmt.Sprintf(`Next %d run times (RFC3339, UTC) of the schedule, calculated when the schedule is created, imported or its cron_expression changes.
+They are not recalculated on refresh (to avoid drift), so they can be in the past`, backupScheduleNextRunsCount))
 	}
 	return s
 }
@@ -118,6 +150,7 @@ func flattenBackupSchedule(schedule *backupv1.BackupSchedule) map[string]interfa
 		backupScheduleCreatedAtFieldName:       formatTime(schedule.GetCreatedAt()),
 		backupScheduleDeletedAtFieldName:       formatTime(schedule.GetDeletedAt()),
 		backupScheduleStatusFieldName:          schedule.GetStatus().String(),
+		backupScheduleEnabledFieldName:         schedule.GetStatus() != backupv1.BackupScheduleStatus_BACKUP_SCHEDULE_STATUS_DISABLED,
 	}
 }
 
@@ -129,11 +162,66 @@ func flattenBackupSchedules(schedules []*backupv1.BackupSchedule) []interface{}
 	return flattened
 }
 
+// flattenBackupScheduleNextRuns returns the next run times of the provided cron expression after from
+// (or an empty list if the expression cannot be parsed).
+func flattenBackupScheduleNextRuns(cronExpression string, from time.Time) []interface{} {
+	cron, err := parseCronExpression(cronExpression)
+	if err != nil {
+		return []interface{}{}
+	}
+	runs := cron.nextRuns(from, backupScheduleNextRunsCount)
+	result := make([]interface{}, len(runs))
+	for i, run := range runs {
+		result[i] = run.Format(time.RFC3339)
+	}
+	return result
+}
+
+// validateBackupScheduleRetentionPeriod warns if the retention period is shorter than the interval
+// between two runs of the schedule, in that case there are periods without any retained backup.
+func validateBackupScheduleRetentionPeriod(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+	cronValue := req.RawConfig.GetAttr(backupScheduleCronExpressionFieldName)
+	retentionValue := req.RawConfig.GetAttr(backupScheduleRetentionPeriodFieldName)
+	if !cronValue.IsKnown() || cronValue.IsNull() || !retentionValue.IsKnown() || retentionValue.IsNull() {
+		return
+	}
+	cron, err := parseCronExpression(cronValue.AsString())
+	if err != nil {
+		// Reported by the attribute validation.
+		return
+	}
+	retention, err := time.ParseDuration(retentionValue.AsString())
+	if err != nil {
+		return
+	}
+	if interval := cron.maxInterval(time.Now()); interval > 0 && retention < interval {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Retention period shorter than schedule interval",
+			Detail: fmt.Sprintf("The retention period (%s) is shorter than the interval between backups (up to %s) of schedule %q, "+
+				"backups will not overlap and there will be periods without any backup available.", retention, interval, cronValue.AsString()),
+			AttributePath: cty.GetAttrPath(backupScheduleRetentionPeriodFieldName),
+		})
+	}
+}
+
 func expandBackupSchedule(d *schema.ResourceData) *backupv1.BackupSchedule {
 	return &backupv1.BackupSchedule{
 		Id:              d.Id(),
 		ClusterId:       d.Get(backupScheduleClusterIDFieldName).(string),
 		Schedule:        d.Get(backupScheduleCronExpressionFieldName).(string),
 		RetentionPeriod: parseDuration(d.Get(backupScheduleRetentionPeriodFieldName).(string)),
+		Status:          expandBackupScheduleStatus(d.Get(backupScheduleEnabledFieldName).(bool)),
+	}
+}
+
+// expandBackupScheduleStatus returns the status of a schedule, which is either active or disabled (paused).
+func expandBackupScheduleStatus(enabled bool) backupv1.BackupScheduleStatus {
+	if enabled {
+		return backupv1.BackupScheduleStatus_BACKUP_SCHEDULE_STATUS_ACTIVE
 	}
+	return backupv1.BackupScheduleStatus_BACKUP_SCHEDULE_STATUS_DISABLED
 }
@@ -1,11 +1,15 @@
 package qdrant
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -39,6 +43,7 @@ func TestFlattenBackupSchedule(t *testing.T) {
 		backupScheduleCreatedAtFieldName:       formatTime(createdAt),
 		backupScheduleDeletedAtFieldName:       formatTime(deletedAt),
 		backupScheduleStatusFieldName:          "BACKUP_SCHEDULE_STATUS_ACTIVE",
+		backupScheduleEnabledFieldName:         true,
 	}
 
 	assert.Equal(t, expected, flattened)
@@ -52,6 +57,7 @@ func TestExpandBackupSchedule(t *testing.T) {
 		ClusterId:       "cluster-id-2",
 		Schedule:        "0 12 * * *",
 		RetentionPeriod: durationpb.New(retentionPeriod),
+		Status:          backupv1.BackupScheduleStatus_BACKUP_SCHEDULE_STATUS_ACTIVE,
 	}
 
 	d := schema.TestResourceDataRaw(t, accountsBackupScheduleResourceSchema(false), map[string]interface{}{
@@ -66,6 +72,18 @@ func TestExpandBackupSchedule(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+func TestExpandBackupSchedulePaused(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, accountsBackupScheduleResourceSchema(false), map[string]interface{}{
+		backupScheduleClusterIDFieldName:       "cluster-id-2",
+		backupScheduleCronExpressionFieldName:  "0 12 * * *",
+		backupScheduleRetentionPeriodFieldName: "168h",
+		backupScheduleEnabledFieldName:         false,
+	})
+
+	result := expandBackupSchedule(d)
+	assert.Equal(t, backupv1.BackupScheduleStatus_BACKUP_SCHEDULE_STATUS_DISABLED, result.GetStatus())
+}
+
 func TestFlattenBackupSchedules(t *testing.T) {
 	createdAt := timestamppb.New(time.Now())
 	retentionPeriod1 := durationpb.New(3 * 24 * time.Hour)
@@ -104,6 +122,7 @@ func TestFlattenBackupSchedules(t *testing.T) {
 			backupScheduleCreatedAtFieldName:       formatTime(createdAt),
 			backupScheduleDeletedAtFieldName:       "",
 			backupScheduleStatusFieldName:          "BACKUP_SCHEDULE_STATUS_ACTIVE",
+			backupScheduleEnabledFieldName:         true,
 		},
 		map[string]interface{}{
 			backupScheduleIDFieldName:              "schedule-id-2",
@@ -114,8 +133,55 @@ func TestFlattenBackupSchedules(t *testing.T) {
 			backupScheduleCreatedAtFieldName:       formatTime(createdAt),
 			backupScheduleDeletedAtFieldName:       "",
 			backupScheduleStatusFieldName:          "BACKUP_SCHEDULE_STATUS_DISABLED",
+			backupScheduleEnabledFieldName:         false,
 		},
 	}
 
 	assert.Equal(t, expected, flattened)
 }
+
+func TestFlattenBackupScheduleNextRuns(t *testing.T) {
+	from := time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC)
+
+	runs := flattenBackupScheduleNextRuns("0 12 * * *", from)
+	assert.Equal(t, []interface{}{
+		"2024-05-10T12:00:00Z",
+		"2024-05-11T12:00:00Z",
+		"2024-05-12T12:00:00Z",
+		"2024-05-13T12:00:00Z",
+		"2024-05-14T12:00:00Z",
+	}, runs)
+
+	assert.Empty(t, flattenBackupScheduleNextRuns("invalid", from))
+}
+
+func TestValidateBackupScheduleRetentionPeriod(t *testing.T) {
+	validate := func(cron, retention cty.Value) diag.Diagnostics {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		validateBackupScheduleRetentionPeriod(context.Background(), schema.ValidateResourceConfigFuncRequest{
+			RawConfig: cty.ObjectVal(map[string]cty.Value{
+				backupScheduleCronExpressionFieldName:  cron,
+				backupScheduleRetentionPeriodFieldName: retention,
+			}),
+		}, resp)
+		return resp.Diagnostics
+	}
+
+	t.Run("retention shorter than interval", func(t *testing.T) {
+		diags := validate(cty.StringVal("@weekly"), cty.StringVal("72h"))
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, cty.GetAttrPath(backupScheduleRetentionPeriodFieldName), diags[0].AttributePath)
+	})
+	t.Run("retention longer than interval", func(t *testing.T) {
+		assert.Empty(t, validate(cty.StringVal("@daily"), cty.StringVal("168h")))
+	})
+	t.Run("unknown values", func(t *testing.T) {
+		assert.Empty(t, validate(cty.UnknownVal(cty.String), cty.StringVal("1h")))
+		assert.Empty(t, validate(cty.StringVal("@weekly"), cty.NullVal(cty.String)))
+	})
+	t.Run("invalid values", func(t *testing.T) {
+		assert.Empty(t, validate(cty.StringVal("invalid"), cty.StringVal("1h")))
+		assert.Empty(t, validate(cty.StringVal("@weekly"), cty.StringVal("1w")))
+	})
+}
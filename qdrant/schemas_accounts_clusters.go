@@ -1,12 +1,14 @@
 package qdrant
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
 	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
@@ -19,6 +21,15 @@ const (
 	clustersAccountIDFieldName = "account_id"
 	clustersClustersFieldName  = "clusters"
 
+	clustersLabelSelectorFieldName = "label_selector"
+	clustersNameRegexFieldName     = "name_regex"
+	clustersCloudProviderFieldName = "cloud_provider"
+	clustersCloudRegionFieldName   = "cloud_region"
+	clustersPhaseFieldName         = "phase"
+	clustersVersionFieldName       = "version"
+	clustersIDsFieldName           = "ids"
+	clustersNamesFieldName         = "names"
+
 	clusterFieldTemplate                               = "Cluster Schema %s field"
 	clusterIdentifierFieldName                         = "id"
 	clusterCreatedAtFieldName                          = "created_at"
@@ -32,9 +43,28 @@ const (
 	clusterPrivateRegionIDFieldName                    = "private_region_id"
 	clusterMarkedForDeletionAtFieldName                = "marked_for_deletion_at"
 	clusterURLFieldName                                = "url"
+	clusterEndpointFieldName                           = "endpoint"
+	clusterEndpointHostFieldName                       = "host"
+	clusterEndpointRestPortFieldName                   = "rest_port"
+	clusterEndpointGrpcPortFieldName                   = "grpc_port"
+	clusterEndpointRestURLFieldName                    = "rest_url"
+	clusterEndpointGrpcURLFieldName                    = "grpc_url"
+	clusterConnectionSnippetsFieldName                 = "connection_snippets"
+	clusterConnectionSnippetPythonFieldName            = "python"
+	clusterConnectionSnippetGoFieldName                = "go"
+	clusterConnectionSnippetJavaScriptFieldName        = "javascript"
 	clusterStatusFieldName                             = "status"
 	clusterStatusVersionFieldName                      = "version"
 	clusterDeleteBackupsOnDestroyFieldName             = "delete_backups_on_destroy"
+	clusterFinalBackupFieldName                        = "final_backup"
+	clusterBackupBeforeUpdateFieldName                 = "backup_before_update"
+	clusterPreUpdateBackupIDFieldName                  = "pre_update_backup_id"
+	clusterChangeImpactFieldName                       = "change_impact"
+	clusterEstimatedPricePerHourFieldName              = "estimated_price_per_hour"
+	clusterEstimatedMonthlyCostFieldName               = "estimated_monthly_cost"
+	clusterEstimatedCostCurrencyFieldName              = "estimated_cost_currency"
+	clusterBackupSettingsEnabledFieldName              = "enabled"
+	clusterBackupSettingsRetentionPeriodFieldName      = "retention_period"
 	clusterStatusNodesUpFieldName                      = "nodes_up"
 	clusterStatusRestartedAtFieldName                  = "restarted_at"
 	clusterStatusPhaseFieldName                        = "phase"
@@ -131,7 +161,9 @@ const (
 )
 
 // accountsClustersSchema defines the schema for a cluster list data-source.
+// The (optional) filters are combined, a cluster should meet all of them to be listed.
 func accountsClustersSchema() map[string]*schema.Schema {
+	validPhases := protoEnumNames(qcCluster.ClusterPhase_name)
 	return map[string]*schema.Schema{
 		clustersAccountIDFieldName: {
 			Description: fmt.Sprintf(clustersFieldTemplate, "Identifier of the account"),
@@ -139,6 +171,53 @@ func accountsClustersSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Optional:    true,
 		},
+		clustersLabelSelectorFieldName: {
+			Description: fmt.Sprintf(clustersFieldTemplate, `Label selector to filter the clusters by (Kubernetes style), e.g. "env=prod,team in (a,b)".
+Supported requirements are "key=value", "key!=value", "key in (v1,v2)", "key notin (v1,v2)", "key" (exists) and "!key" (does not exist)`),
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validateLabelSelector),
+		},
+		clustersNameRegexFieldName: {
+			Description:      fmt.Sprintf(clustersFieldTemplate, "Regular expression to filter the clusters by name"),
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+		},
+		clustersCloudProviderFieldName: {
+			Description: fmt.Sprintf(clustersFieldTemplate, "Cloud provider to filter the clusters by"),
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		clustersCloudRegionFieldName: {
+			Description: fmt.Sprintf(clustersFieldTemplate, "Cloud provider region to filter the clusters by"),
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		clustersPhaseFieldName: {
+			Description:      fmt.Sprintf(clustersFieldTemplate, fmt.Sprintf("Phase to filter the clusters by. Must be one of: %s", strings.Join(validPhases, ", "))),
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validPhases, false)),
+		},
+		clustersVersionFieldName: {
+			Description:      fmt.Sprintf(clustersFieldTemplate, `Qdrant version (as running) to filter the clusters by, e.g. "v1.13" matches all v1.13.x versions`),
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validateQdrantVersion),
+		},
+		clustersIDsFieldName: {
+			Description: fmt.Sprintf(clustersFieldTemplate, "Identifiers of the listed clusters"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		clustersNamesFieldName: {
+			Description: fmt.Sprintf(clustersFieldTemplate, "Names of the listed clusters"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
 		clustersClustersFieldName: {
 			Description: fmt.Sprintf(clustersFieldTemplate, "List of clusters"),
 			Type:        schema.TypeList,
@@ -151,6 +230,40 @@ func accountsClustersSchema() map[string]*schema.Schema {
 	}
 }
 
+// accountsClusterLookupSchema defines the schema for the cluster data-source.
+// The cluster can be looked up by ID, or by name and/or labels (which should match a single cluster).
+func accountsClusterLookupSchema() map[string]*schema.Schema {
+	s := accountsClusterSchema(true)
+	lookupKeys := []string{clusterIdentifierFieldName, clusterNameFieldName, clusterLabelsFieldName}
+	s[clusterIdentifierFieldName] = &schema.Schema{
+		Description:   fmt.Sprintf(clusterFieldTemplate, "Identifier of the cluster (either the id, or the name and/or labels should be provided)"),
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{clusterNameFieldName, clusterLabelsFieldName},
+		AtLeastOneOf:  lookupKeys,
+	}
+	s[clusterNameFieldName] = &schema.Schema{
+		Description:  fmt.Sprintf(clusterFieldTemplate, "Name of the cluster (used to look up the cluster if no id is provided)"),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		AtLeastOneOf: lookupKeys,
+	}
+	s[clusterLabelsFieldName] = &schema.Schema{
+		Description:  fmt.Sprintf(clusterFieldTemplate, "List of labels associated with the cluster (used to look up the cluster if no id is provided, the cluster should have all provided labels)"),
+		Type:         schema.TypeSet,
+		Optional:     true,
+		Computed:     true,
+		AtLeastOneOf: lookupKeys,
+		Elem: &schema.Resource{
+			Schema: keyValSchema(false),
+		},
+		Set: keyValHashFunc,
+	}
+	return s
+}
+
 // accountsClusterSchema defines the schema for a cluster resource or data-source.
 func accountsClusterSchema(asDataSource bool) map[string]*schema.Schema {
 	maxItems := 1
@@ -158,7 +271,7 @@ func accountsClusterSchema(asDataSource bool) map[string]*schema.Schema {
 		// We should not set Max Items
 		maxItems = 0
 	}
-	return map[string]*schema.Schema{
+	s := map[string]*schema.Schema{
 		clusterIdentifierFieldName: {
 			Description: fmt.Sprintf(clusterFieldTemplate, "Identifier of the cluster"),
 			Type:        schema.TypeString,
@@ -194,7 +307,7 @@ func accountsClusterSchema(asDataSource bool) map[string]*schema.Schema {
 		},
 		clusterCloudProviderFieldName: {
 			Description: fmt.Sprintf(clusterFieldTemplate, `Cloud provider where the cluster is hosted.
-Must match one of the provider IDs returned by the "qdrant.cloud.platform.v1.PlatformService.ListCloudProviders" method.
+Must match one of the provider IDs returned by the "qdrant.cloud.platform.v1.PlatformService.ListCloudProviders" method (see the "qdrant-cloud_cloud_providers" data source).
 For Hybrid cloud this should be "hybrid".`),
 			Type:     schema.TypeString,
 			Required: !asDataSource,
@@ -203,7 +316,7 @@ For Hybrid cloud this should be "hybrid".`),
 		},
 		clusterCloudRegionFieldName: {
 			Description: fmt.Sprintf(clusterFieldTemplate, `Cloud provider region where the cluster is hosted.
-Must match one of the region IDs returned by the "qdrant.cloud.platform.v1.PlatformService.ListCloudProviderRegions" method.
+Must match one of the region IDs returned by the "qdrant.cloud.platform.v1.PlatformService.ListCloudProviderRegions" method (see the "qdrant-cloud_cloud_provider_regions" data source).
 For hybrid this should be the hybrid cloud environment ID.`),
 			Type:     schema.TypeString,
 			Required: !asDataSource,
@@ -229,6 +342,22 @@ For hybrid this should be the hybrid cloud environment ID.`),
 			Type:        schema.TypeString,
 			Computed:    true,
 		},
+		clusterEndpointFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, "The connection details of the endpoint of the Qdrant cluster"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: accountsClusterEndpointSchema(),
+			},
+		},
+		clusterConnectionSnippetsFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, "Snippets to connect to the Qdrant cluster using the client libraries (the API key is read from the QDRANT_API_KEY environment variable)"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: accountsClusterConnectionSnippetsSchema(),
+			},
+		},
 		configurationFieldName: {
 			Description: fmt.Sprintf(clusterFieldTemplate, "The configuration options of a cluster"),
 			Type:        schema.TypeList, // There is a single required item only, no need for a set.
@@ -253,7 +382,161 @@ For hybrid this should be the hybrid cloud environment ID.`),
 			Optional:    true,
 			Default:     true,
 		},
+		clusterFinalBackupFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, `Final backup taken before the cluster is destroyed.
+When enabled, a manual backup is created and awaited before the cluster is deleted, and existing backups are kept regardless of delete_backups_on_destroy.`),
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: accountsClusterBackupSettingsSchema("before the cluster is destroyed"),
+			},
+		},
+		clusterBackupBeforeUpdateFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, `Backup taken before a disruptive update of the cluster.
+When enabled, a manual backup is created and awaited before a disruptive change is applied, i.e. a change which isn't classified as in-place in change_impact
+(e.g. a version upgrade, a package change, a decrease of the number of nodes or a change which restarts the nodes).`),
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: accountsClusterBackupSettingsSchema("before a disruptive update"),
+			},
+		},
+		clusterTlsMaterialFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, `PEM encoded TLS material stored in the secrets referenced by configuration.database_configuration.tls.
+Client-side only: validated during plan (format and expiry) but never sent to the API. Not imported`),
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: accountsClusterTlsMaterialSchema(),
+			},
+		},
+		clusterTlsCertificateNotAfterFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, "Expiry (RFC3339) of the certificate in tls_material (empty if none)"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		clusterTlsCertificateFingerprintFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, "SHA-256 fingerprint (colon separated hex) of the certificate in tls_material (empty if none)"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		clusterPreUpdateBackupIDFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, "Identifier of the last backup created by backup_before_update"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		clusterChangeImpactFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, `Impact of the planned changes of the cluster (empty if none), classified per field as "in-place", "rolling restart", "downtime" (single node clusters), "resharding" (decrease of the number of nodes) or "irreversible" (e.g. disk growth), ordered by severity.
+This is the plan-time signal of the impact, as warnings can only be reported when the update is applied. Invalid changes (e.g. shrinking the disk) fail the plan`),
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		clusterEstimatedPricePerHourFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, "Estimated price per hour (based on the booking catalog, including additional disk and storage tier) in estimated_cost_currency"),
+			Type:        schema.TypeFloat,
+			Computed:    true,
+		},
+		clusterEstimatedMonthlyCostFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, "Estimated monthly cost (730 hours) in estimated_cost_currency"),
+			Type:        schema.TypeFloat,
+			Computed:    true,
+		},
+		clusterEstimatedCostCurrencyFieldName: {
+			Description: fmt.Sprintf(clusterFieldTemplate, `Currency of the estimated cost, empty (and the estimated cost 0) if no estimate is available, e.g. for hybrid cloud clusters (not priced by the catalog).
+The estimate is calculated during plan when the package, number of nodes, disk or storage tier change, it isn't refreshed (use the qdrant-cloud_accounts_cost_report data source for current prices)`),
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	if asDataSource {
+		// The client-side backup settings and TLS material, and the values planned from them, are only managed by the resource.
+		// The estimated cost is only calculated during plan, the cost report data source provides it for existing clusters.
+		for _, k := range []string{clusterFinalBackupFieldName, clusterBackupBeforeUpdateFieldName, clusterPreUpdateBackupIDFieldName, clusterChangeImpactFieldName,
+			clusterTlsMaterialFieldName, clusterTlsCertificateNotAfterFieldName, clusterTlsCertificateFingerprintFieldName,
+			clusterEstimatedPricePerHourFieldName, clusterEstimatedMonthlyCostFieldName, clusterEstimatedCostCurrencyFieldName} {
+			delete(s, k)
+		}
+	}
+	return s
+}
+
+// accountsClusterBackupSettingsSchema defines the schema for the on-demand backup settings of a cluster
+// (final_backup and backup_before_update). These settings are client-side only.
+// when: Describes when the backup is taken, used in the descriptions.
+func accountsClusterBackupSettingsSchema(when string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		clusterBackupSettingsEnabledFieldName: {
+			Description: fmt.Sprintf("Whether to create a backup %s.", when),
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+		},
+		clusterBackupSettingsRetentionPeriodFieldName: {
+			Description:      fmt.Sprintf("Retention period of the backup created %s (Go duration, e.g. \"720h\"). If omitted the backup is kept until deleted.", when),
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressDurationDiff,
+			ValidateDiagFunc: validation.ToDiagFunc(validateDuration),
+		},
+	}
+}
+
+// clusterBackupSettingsGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type clusterBackupSettingsGetter interface {
+	Get(key string) interface{}
+}
+
+// expandClusterBackupSettings returns whether the on-demand backup configured in the provided block
+// (final_backup or backup_before_update) is enabled and the retention period to use for it (nil if not provided).
+func expandClusterBackupSettings(d clusterBackupSettingsGetter, fieldName string) (bool, *durationpb.Duration) {
+	items := d.Get(fieldName).([]interface{})
+	if len(items) == 0 || items[0] == nil {
+		return false, nil
+	}
+	item := items[0].(map[string]interface{})
+	enabled, _ := item[clusterBackupSettingsEnabledFieldName].(bool)
+	if !enabled {
+		return false, nil
+	}
+	var retention *durationpb.Duration
+	if v, ok := item[clusterBackupSettingsRetentionPeriodFieldName].(string); ok && v != "" {
+		retention = parseDuration(v)
+	}
+	return true, retention
+}
+
+// clusterChangeGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type clusterChangeGetter interface {
+	GetChange(key string) (interface{}, interface{})
+}
+
+// clusterPreUpdateBackupDetector is implemented by both schema.ResourceData and schema.ResourceDiff.
+type clusterPreUpdateBackupDetector interface {
+	clusterBackupSettingsGetter
+	clusterChangeDetector
+	Id() string
+}
+
+// isPreUpdateBackupPlanned returns true if a backup is created (backup_before_update) before the change of an existing cluster is applied.
+func isPreUpdateBackupPlanned(d clusterPreUpdateBackupDetector) bool {
+	if d.Id() == "" {
+		return false
+	}
+	enabled, _ := expandClusterBackupSettings(d, clusterBackupBeforeUpdateFieldName)
+	return enabled && isDisruptiveClusterChange(d)
+}
+
+// planClusterPreUpdateBackupID marks pre_update_backup_id as computed if a backup is created before the update,
+// so the identifier of the new backup is expected by the plan.
+func planClusterPreUpdateBackupID(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !isPreUpdateBackupPlanned(d) {
+		return nil
 	}
+	return d.SetNewComputed(clusterPreUpdateBackupIDFieldName)
 }
 
 // accountsClusterConfigurationSchema defines the schema for a cluster configuration resource or data-source.
@@ -757,19 +1040,19 @@ func clusterStorageConfigurationSchema(asDataSource bool) map[string]*schema.Sch
 	return map[string]*schema.Schema{
 		clusterStorageTierTypeFieldName: storageTierType,
 		clusterDatabaseStorageClassFieldName: {
-			Description: "The storage class to use for the database storage, if different from the environment default. Hybrid cloud clusters only.",
+			Description: "The storage class to use for the database storage, if different from the environment default. Hybrid cloud clusters only, must be one of the storage classes of the environment (and allow volume expansion if the disk grows).",
 			Type:        schema.TypeString,
 			Optional:    !asDataSource,
 			Computed:    true,
 		},
 		clusterSnapshotStorageClassFieldName: {
-			Description: "The storage class to use for the snapshot storage, if different from the environment default. Hybrid cloud clusters only.",
+			Description: "The storage class to use for the snapshot storage, if different from the environment default. Hybrid cloud clusters only, must be one of the storage classes of the environment.",
 			Type:        schema.TypeString,
 			Optional:    !asDataSource,
 			Computed:    true,
 		},
 		clusterVolumeSnapshotClassFieldName: {
-			Description: "The volume snapshot class to use for the database storage, if different from the environment default. Hybrid cloud clusters only.",
+			Description: "The volume snapshot class to use for the database storage, if different from the environment default. Hybrid cloud clusters only, must be one of the volume snapshot classes of the environment (required if final_backup or backup_before_update is enabled, or an existing cluster has a backup schedule, and the environment has no default; only checked during plan once the environment reports its storage classes).",
 			Type:        schema.TypeString,
 			Optional:    !asDataSource,
 			Computed:    true,
@@ -1415,6 +1698,8 @@ func flattenCluster(cluster *qcCluster.Cluster) map[string]interface{} {
 		clusterPrivateRegionIDFieldName:     privateRegionIdStr,
 		clusterMarkedForDeletionAtFieldName: formatTime(cluster.GetDeletedAt()),
 		clusterURLFieldName:                 cluster.GetState().GetEndpoint().GetUrl(),
+		clusterEndpointFieldName:            flattenClusterEndpoint(cluster.GetState().GetEndpoint()),
+		clusterConnectionSnippetsFieldName:  flattenClusterConnectionSnippets(cluster.GetState().GetEndpoint()),
 		configurationFieldName:              flattenClusterConfiguration(cluster.GetConfiguration(), jwtRbac),
 		clusterStatusFieldName:              flattenClusterState(cluster.GetState()),
 	}
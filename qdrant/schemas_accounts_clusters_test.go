@@ -151,6 +151,16 @@ func TestResourceClusterFlatten(t *testing.T) {
 		clusterPrivateRegionIDFieldName:     "",
 		clusterMarkedForDeletionAtFieldName: formatTime(cluster.GetDeletedAt()),
 		clusterURLFieldName:                 cluster.GetState().GetEndpoint().GetUrl(),
+		clusterEndpointFieldName: []interface{}{
+			map[string]interface{}{
+				clusterEndpointHostFieldName:     "example.com",
+				clusterEndpointRestPortFieldName: 6333,
+				clusterEndpointGrpcPortFieldName: 6334,
+				clusterEndpointRestURLFieldName:  "http://example.com:6333",
+				clusterEndpointGrpcURLFieldName:  "http://example.com:6334",
+			},
+		},
+		clusterConnectionSnippetsFieldName: flattenClusterConnectionSnippets(cluster.GetState().GetEndpoint()),
 		clusterStatusFieldName: []interface{}{
 			map[string]interface{}{
 				clusterStatusVersionFieldName:     cluster.GetState().GetVersion(),
@@ -731,6 +741,29 @@ func TestClusterConfigSchemaDataSourceFieldsAreComputed(t *testing.T) {
 	}
 }
 
+func TestClusterSchemaResourceOnlyFields(t *testing.T) {
+	resourceOnly := []string{
+		clusterFinalBackupFieldName,
+		clusterBackupBeforeUpdateFieldName,
+		clusterPreUpdateBackupIDFieldName,
+		clusterChangeImpactFieldName,
+		clusterTlsMaterialFieldName,
+		clusterTlsCertificateNotAfterFieldName,
+		clusterTlsCertificateFingerprintFieldName,
+		clusterEstimatedPricePerHourFieldName,
+		clusterEstimatedMonthlyCostFieldName,
+		clusterEstimatedCostCurrencyFieldName,
+	}
+	resourceSchema := accountsClusterSchema(false)
+	dataSourceSchema := accountsClusterSchema(true)
+	lookupSchema := accountsClusterLookupSchema()
+	for _, field := range resourceOnly {
+		assert.Contains(t, resourceSchema, field)
+		assert.NotContains(t, dataSourceSchema, field)
+		assert.NotContains(t, lookupSchema, field)
+	}
+}
+
 // TestFlattenClusterConfigurationExplicitUnspecifiedPointers verifies that even when
 // the backend explicitly sends UNSPECIFIED enum pointers (not just nil), they are
 // excluded from the flattened output.
@@ -1157,3 +1190,143 @@ func TestClusterPrivateRegionIDExpandHonorsUserValue(t *testing.T) {
 	assert.Equal(t, region, cluster.GetCloudProviderRegionId(),
 		"a user-set private_region_id must map onto cloud_provider_region_id for hybrid")
 }
+
+func TestExpandClusterBackupSettings(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, accountsClusterSchema(false), map[string]interface{}{})
+		enabled, retention := expandClusterBackupSettings(d, clusterFinalBackupFieldName)
+		assert.False(t, enabled)
+		assert.Nil(t, retention)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, accountsClusterSchema(false), map[string]interface{}{
+			clusterFinalBackupFieldName: []interface{}{
+				map[string]interface{}{
+					clusterBackupSettingsEnabledFieldName:         false,
+					clusterBackupSettingsRetentionPeriodFieldName: "24h",
+				},
+			},
+		})
+		enabled, retention := expandClusterBackupSettings(d, clusterFinalBackupFieldName)
+		assert.False(t, enabled)
+		assert.Nil(t, retention)
+	})
+	t.Run("enabled with retention period", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, accountsClusterSchema(false), map[string]interface{}{
+			clusterFinalBackupFieldName: []interface{}{
+				map[string]interface{}{
+					clusterBackupSettingsRetentionPeriodFieldName: "720h",
+				},
+			},
+		})
+		enabled, retention := expandClusterBackupSettings(d, clusterFinalBackupFieldName)
+		assert.True(t, enabled)
+		require.NotNil(t, retention)
+		assert.Equal(t, 720*time.Hour, retention.AsDuration())
+	})
+	t.Run("enabled without retention period", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, accountsClusterSchema(false), map[string]interface{}{
+			clusterFinalBackupFieldName: []interface{}{
+				map[string]interface{}{
+					clusterBackupSettingsEnabledFieldName: true,
+				},
+			},
+		})
+		enabled, retention := expandClusterBackupSettings(d, clusterFinalBackupFieldName)
+		assert.True(t, enabled)
+		assert.Nil(t, retention)
+	})
+}
+
+// fakeClusterChange implements clusterChangeGetter for testing.
+type fakeClusterChange map[string][2]interface{}
+
+func (f fakeClusterChange) GetChange(key string) (interface{}, interface{}) {
+	if v, ok := f[key]; ok {
+		return v[0], v[1]
+	}
+	return nil, nil
+}
+
+func TestIsDisruptiveClusterChange(t *testing.T) {
+	versionKey := "configuration.0.version"
+	nodesKey := "configuration.0.number_of_nodes"
+	packageKey := "configuration.0.node_configuration.0.package_id"
+	base := func() fakeClusterChangeDetector {
+		return fakeClusterChangeDetector{fakeClusterChange{
+			versionKey: {"v1.13.0", "v1.13.0"},
+			nodesKey:   {3, 3},
+			packageKey: {"pkg-1", "pkg-1"},
+		}}
+	}
+	t.Run("no change", func(t *testing.T) {
+		assert.False(t, isDisruptiveClusterChange(base()))
+	})
+	t.Run("version upgrade", func(t *testing.T) {
+		c := base()
+		c.fakeClusterChange[versionKey] = [2]interface{}{"v1.13.0", "v1.14.0"}
+		assert.True(t, isDisruptiveClusterChange(c))
+	})
+	t.Run("version not set before", func(t *testing.T) {
+		c := base()
+		c.fakeClusterChange[versionKey] = [2]interface{}{"", "v1.14.0"}
+		assert.False(t, isDisruptiveClusterChange(c))
+	})
+	t.Run("node count decrease", func(t *testing.T) {
+		c := base()
+		c.fakeClusterChange[nodesKey] = [2]interface{}{3, 1}
+		assert.True(t, isDisruptiveClusterChange(c))
+	})
+	t.Run("node count increase", func(t *testing.T) {
+		c := base()
+		c.fakeClusterChange[nodesKey] = [2]interface{}{1, 3}
+		assert.False(t, isDisruptiveClusterChange(c))
+	})
+	t.Run("package change", func(t *testing.T) {
+		c := base()
+		c.fakeClusterChange[packageKey] = [2]interface{}{"pkg-1", "pkg-2"}
+		assert.True(t, isDisruptiveClusterChange(c))
+	})
+	t.Run("restart of the nodes", func(t *testing.T) {
+		c := base()
+		c.fakeClusterChange["configuration.0.database_configuration"] = [2]interface{}{[]interface{}{}, []interface{}{map[string]interface{}{}}}
+		assert.True(t, isDisruptiveClusterChange(c))
+	})
+	t.Run("in-place change", func(t *testing.T) {
+		c := base()
+		c.fakeClusterChange["name"] = [2]interface{}{"a", "b"}
+		assert.False(t, isDisruptiveClusterChange(c))
+	})
+}
+
+// fakePreUpdateBackupDetector implements clusterPreUpdateBackupDetector for testing.
+type fakePreUpdateBackupDetector struct {
+	fakeClusterChangeDetector
+	id     string
+	values map[string]interface{}
+}
+
+func (f fakePreUpdateBackupDetector) Id() string                 { return f.id }
+func (f fakePreUpdateBackupDetector) Get(key string) interface{} { return f.values[key] }
+
+func TestIsPreUpdateBackupPlanned(t *testing.T) {
+	enabled := []interface{}{map[string]interface{}{"enabled": true}}
+	upgrade := fakeClusterChangeDetector{fakeClusterChange{"configuration.0.version": {"v1.13.0", "v1.14.0"}}}
+	t.Run("disruptive change with backup enabled", func(t *testing.T) {
+		d := fakePreUpdateBackupDetector{upgrade, "cluster-1", map[string]interface{}{clusterBackupBeforeUpdateFieldName: enabled}}
+		assert.True(t, isPreUpdateBackupPlanned(d))
+	})
+	t.Run("backup not configured", func(t *testing.T) {
+		d := fakePreUpdateBackupDetector{upgrade, "cluster-1", map[string]interface{}{clusterBackupBeforeUpdateFieldName: []interface{}{}}}
+		assert.False(t, isPreUpdateBackupPlanned(d))
+	})
+	t.Run("new cluster", func(t *testing.T) {
+		d := fakePreUpdateBackupDetector{upgrade, "", map[string]interface{}{clusterBackupBeforeUpdateFieldName: enabled}}
+		assert.False(t, isPreUpdateBackupPlanned(d))
+	})
+	t.Run("non disruptive change", func(t *testing.T) {
+		d := fakePreUpdateBackupDetector{fakeClusterChangeDetector{fakeClusterChange{"configuration.0.number_of_nodes": {1, 3}}}, "cluster-1",
+			map[string]interface{}{clusterBackupBeforeUpdateFieldName: enabled}}
+		assert.False(t, isPreUpdateBackupPlanned(d))
+	})
+}
@@ -0,0 +1,191 @@
+package qdrant
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+const (
+	costReportFieldTemplate = "Cost Report Schema %s field"
+
+	costReportAccountIDFieldName     = "account_id"
+	costReportClustersFieldName      = "clusters"
+	costReportTotalsFieldName        = "totals"
+	costReportClusterIDFieldName     = "cluster_id"
+	costReportEstimatedFieldName     = "estimated"
+	costReportCurrencyFieldName      = "currency"
+	costReportPricePerHourFieldName  = "price_per_hour"
+	costReportMonthlyCostFieldName   = "monthly_cost"
+	costReportClusterCountFieldName  = "cluster_count"
+	costReportUnpricedCountFieldName = "unpriced_cluster_count"
+)
+
+// accountsCostReportSchema defines the Terraform schema for the accounts_cost_report data source.
+func accountsCostReportSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		costReportAccountIDFieldName: {
+			Description: "The account ID (UUID). Defaults to the provider-level account_id.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		costReportClustersFieldName: {
+			Description: "Estimated cost of every cluster in the account.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					costReportClusterIDFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Identifier of the cluster"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					clusterNameFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Name of the cluster"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					clusterCloudProviderFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Cloud provider where the cluster resides"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					clusterCloudRegionFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Cloud region where the cluster resides"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					packageIDFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Identifier of the package of the cluster"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					numberOfNodesFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Number of nodes of the cluster"),
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					costReportEstimatedFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Whether the cost of the cluster could be estimated (false for hybrid cloud clusters and packages missing from the catalog)"),
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					costReportCurrencyFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Currency of the estimated cost"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					costReportPricePerHourFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Estimated price per hour"),
+						Type:        schema.TypeFloat,
+						Computed:    true,
+					},
+					costReportMonthlyCostFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Estimated monthly cost (730 hours)"),
+						Type:        schema.TypeFloat,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		costReportTotalsFieldName: {
+			Description: "Estimated cost of all clusters in the account, summed per currency.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					costReportCurrencyFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Currency of the totals"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					costReportClusterCountFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Number of clusters included in the totals"),
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+					costReportPricePerHourFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Total estimated price per hour"),
+						Type:        schema.TypeFloat,
+						Computed:    true,
+					},
+					costReportMonthlyCostFieldName: {
+						Description: fmt.Sprintf(costReportFieldTemplate, "Total estimated monthly cost (730 hours)"),
+						Type:        schema.TypeFloat,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		costReportUnpricedCountFieldName: {
+			Description: "Number of clusters whose cost could not be estimated (and are not part of the totals).",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+}
+
+// clusterCostEstimator estimates the cost of the provided cluster, returns false if the cluster cannot be priced.
+type clusterCostEstimator func(cluster *qcCluster.Cluster) (clusterCostEstimate, bool)
+
+// costReportTotal is the running total of the estimated cost of clusters (in a single currency).
+type costReportTotal struct {
+	count        int
+	pricePerHour float64
+}
+
+// flattenCostReport creates the cost report of the provided clusters for easy storage in Terraform.
+// It returns the per cluster estimates, the totals per currency (sorted by currency) and the number of unpriced clusters.
+func flattenCostReport(clusters []*qcCluster.Cluster, estimator clusterCostEstimator) ([]interface{}, []interface{}, int) {
+	items := make([]interface{}, 0, len(clusters))
+	totals := map[string]*costReportTotal{}
+	unpriced := 0
+	for _, cluster := range clusters {
+		estimate, ok := estimator(cluster)
+		if !ok {
+			unpriced++
+			estimate = clusterCostEstimate{}
+		} else {
+			total, found := totals[estimate.Currency]
+			if !found {
+				total = &costReportTotal{}
+				totals[estimate.Currency] = total
+			}
+			total.count++
+			total.pricePerHour += estimate.PricePerHour
+		}
+		config := cluster.GetConfiguration()
+		items = append(items, map[string]interface{}{
+			costReportClusterIDFieldName:    cluster.GetId(),
+			clusterNameFieldName:            cluster.GetName(),
+			clusterCloudProviderFieldName:   cluster.GetCloudProviderId(),
+			clusterCloudRegionFieldName:     cluster.GetCloudProviderRegionId(),
+			packageIDFieldName:              config.GetPackageId(),
+			numberOfNodesFieldName:          int(config.GetNumberOfNodes()),
+			costReportEstimatedFieldName:    ok,
+			costReportCurrencyFieldName:     estimate.Currency,
+			costReportPricePerHourFieldName: estimate.PricePerHour,
+			costReportMonthlyCostFieldName:  estimate.MonthlyCost,
+		})
+	}
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	flattenedTotals := make([]interface{}, 0, len(currencies))
+	for _, currency := range currencies {
+		total := totals[currency]
+		flattenedTotals = append(flattenedTotals, map[string]interface{}{
+			costReportCurrencyFieldName:     currency,
+			costReportClusterCountFieldName: total.count,
+			costReportPricePerHourFieldName: roundPrice(total.pricePerHour, 5),
+			costReportMonthlyCostFieldName:  roundPrice(total.pricePerHour*hoursPerMonth, 2),
+		})
+	}
+	return items, flattenedTotals, unpriced
+}
@@ -0,0 +1,69 @@
+package qdrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	qcCluster "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/cluster/v1"
+)
+
+func TestFlattenCostReport(t *testing.T) {
+	clusters := []*qcCluster.Cluster{
+		{Id: "c1", Name: "one", CloudProviderId: "aws", CloudProviderRegionId: "eu-central-1", Configuration: &qcCluster.ClusterConfiguration{PackageId: "pkg-1", NumberOfNodes: 1}},
+		{Id: "c2", Name: "two", CloudProviderId: "aws", CloudProviderRegionId: "eu-central-1", Configuration: &qcCluster.ClusterConfiguration{PackageId: "pkg-1", NumberOfNodes: 3}},
+		{Id: "c3", Name: "three", CloudProviderId: "gcp", CloudProviderRegionId: "europe-west3", Configuration: &qcCluster.ClusterConfiguration{PackageId: "pkg-2", NumberOfNodes: 1}},
+		{Id: "c4", Name: "hybrid", CloudProviderId: hybridCloudClusterID, CloudProviderRegionId: "env-1", Configuration: &qcCluster.ClusterConfiguration{NumberOfNodes: 1}},
+	}
+	estimates := map[string]clusterCostEstimate{
+		"c1": {PricePerHour: 0.1, MonthlyCost: 73, Currency: "usd"},
+		"c2": {PricePerHour: 0.3, MonthlyCost: 219, Currency: "usd"},
+		"c3": {PricePerHour: 0.2, MonthlyCost: 146, Currency: "eur"},
+	}
+	items, totals, unpriced := flattenCostReport(clusters, func(cluster *qcCluster.Cluster) (clusterCostEstimate, bool) {
+		estimate, ok := estimates[cluster.GetId()]
+		return estimate, ok
+	})
+
+	assert.Len(t, items, 4)
+	assert.Equal(t, map[string]interface{}{
+		costReportClusterIDFieldName:    "c2",
+		clusterNameFieldName:            "two",
+		clusterCloudProviderFieldName:   "aws",
+		clusterCloudRegionFieldName:     "eu-central-1",
+		packageIDFieldName:              "pkg-1",
+		numberOfNodesFieldName:          3,
+		costReportEstimatedFieldName:    true,
+		costReportCurrencyFieldName:     "usd",
+		costReportPricePerHourFieldName: 0.3,
+		costReportMonthlyCostFieldName:  219.0,
+	}, items[1])
+	assert.Equal(t, false, items[3].(map[string]interface{})[costReportEstimatedFieldName])
+	assert.Equal(t, 0.0, items[3].(map[string]interface{})[costReportPricePerHourFieldName])
+
+	// Totals are summed per currency, sorted by currency
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			costReportCurrencyFieldName:     "eur",
+			costReportClusterCountFieldName: 1,
+			costReportPricePerHourFieldName: 0.2,
+			costReportMonthlyCostFieldName:  146.0,
+		},
+		map[string]interface{}{
+			costReportCurrencyFieldName:     "usd",
+			costReportClusterCountFieldName: 2,
+			costReportPricePerHourFieldName: 0.4,
+			costReportMonthlyCostFieldName:  292.0,
+		},
+	}, totals)
+	assert.Equal(t, 1, unpriced)
+}
+
+func TestFlattenCostReportEmpty(t *testing.T) {
+	items, totals, unpriced := flattenCostReport(nil, func(*qcCluster.Cluster) (clusterCostEstimate, bool) {
+		return clusterCostEstimate{}, false
+	})
+	assert.Equal(t, []interface{}{}, items)
+	assert.Equal(t, []interface{}{}, totals)
+	assert.Equal(t, 0, unpriced)
+}
@@ -2,7 +2,6 @@ package qdrant
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 
 	"github.com/goccy/go-yaml"
@@ -14,6 +13,11 @@ import (
 )
 
 const (
+	hcEnvsFieldTemplate                    = "Hybrid cloud environments Schema %s field"
+	hcEnvsAccountIdFieldName               = "account_id"
+	hcEnvsPhaseFieldName                   = "phase"
+	hcEnvsHybridCloudEnvironmentsFieldName = "hybrid_cloud_environments"
+
 	hcEnvFieldTemplate                       = "Hybrid cloud environment Schema %s field"
 	hcEnvIdFieldName                         = "id"
 	hcEnvAccountIdFieldName                  = "account_id"
@@ -27,6 +31,11 @@ const (
 	hcEnvBootstrapCommandsFieldName          = "bootstrap_commands"
 	hcEnvBootstrapCommandsVersionFieldName   = "bootstrap_commands_version"
 	hcEnvStatusFieldName                     = "status"
+	hcEnvWaitForReadyFieldName               = "wait_for_ready"
+	hcEnvRegenerateOnChangeFieldName         = "regenerate_on_change"
+	hcEnvForceDestroyFieldName               = "force_destroy"
+	hcEnvCaCertificatesNotAfterFieldName     = "ca_certificates_not_after"
+	hcEnvCaCertificatesFingerprintsFieldName = "ca_certificates_fingerprints"
 
 	hcEnvCfgLastModifiedAtFieldName             = "last_modified_at"
 	hcEnvCfgHttpProxyUrlFieldName               = "http_proxy_url"
@@ -73,8 +82,13 @@ const (
 	hcEnvStatusVSCDriverField                    = "driver"
 )
 
-func accountsHybridCloudEnvironmentSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+func accountsHybridCloudEnvironmentSchema(asDataSource bool) map[string]*schema.Schema {
+	maxItems := 1
+	if asDataSource {
+		// We should not set Max Items
+		maxItems = 0
+	}
+	s := map[string]*schema.Schema{
 		hcEnvIdFieldName: {
 			Description: fmt.Sprintf(hcEnvFieldTemplate, "ID"),
 			Type:        schema.TypeString,
@@ -89,14 +103,16 @@ func accountsHybridCloudEnvironmentSchema() map[string]*schema.Schema {
 		hcEnvNameFieldName: {
 			Description: fmt.Sprintf(hcEnvFieldTemplate, "Name"),
 			Type:        schema.TypeString,
-			Required:    true,
+			Required:    !asDataSource,
+			Computed:    asDataSource,
 		},
 		hcEnvConfigurationFieldName: {
 			Description: fmt.Sprintf(hcEnvFieldTemplate, "Configuration"),
 			Type:        schema.TypeList,
-			Required:    true,
-			MaxItems:    1,
-			Elem:        &schema.Resource{Schema: accountsHybridCloudEnvironmentConfigurationSchema()},
+			Required:    !asDataSource,
+			Computed:    asDataSource,
+			MaxItems:    maxItems,
+			Elem:        &schema.Resource{Schema: accountsHybridCloudEnvironmentConfigurationSchema(asDataSource)},
 		},
 		hcEnvCreatedAtFieldName: {
 			Description: fmt.Sprintf(hcEnvFieldTemplate, "Creation timestamp"),
@@ -118,6 +134,17 @@ func accountsHybridCloudEnvironmentSchema() map[string]*schema.Schema {
 			Type:        schema.TypeBool,
 			Computed:    true,
 		},
+		hcEnvCaCertificatesNotAfterFieldName: {
+			Description: fmt.Sprintf(hcEnvFieldTemplate, "Earliest expiry (RFC3339) of the CA certificates in configuration.ca_certificates (empty if none)"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvCaCertificatesFingerprintsFieldName: {
+			Description: fmt.Sprintf(hcEnvFieldTemplate, "SHA-256 fingerprints (colon separated hex) of the CA certificates in configuration.ca_certificates, in order"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
 
 		hcEnvStatusFieldName: {
 			Description: "Current status of the hybrid cloud environment (read-only).",
@@ -134,32 +161,147 @@ func accountsHybridCloudEnvironmentSchema() map[string]*schema.Schema {
 			Sensitive:   true,
 		},
 
+		hcEnvBootstrapFieldName: {
+			Description: fmt.Sprintf(hcEnvFieldTemplate, "The bootstrap commands parsed into structured Kubernetes resources (namespace, Helm releases, secrets and manifests), e.g. for the helm_release and kubernetes_manifest resources"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Sensitive:   true,
+			Elem:        &schema.Resource{Schema: accountsHybridCloudEnvironmentBootstrapSchema()},
+		},
+
 		hcEnvBootstrapCommandsVersionFieldName: {
 			Description: "Version knob to (re)generate bootstrap commands. -1 = never generate, 0 = idle/do not (re)generate, >0 = generate/rotate.",
 			Type:        schema.TypeInt,
 			Optional:    true,
 			Computed:    true,
 		},
+
+		hcEnvWaitForReadyFieldName: {
+			Description: `Wait (up to the create/update timeout) until the environment is ready and allows the creation of clusters, i.e. the bootstrap commands have been applied and the agent is connected.
+On create this only succeeds if the bootstrap commands are applied outside of this apply (e.g. by another pipeline), otherwise the wait times out with an error (which taints the environment).
+If the environment is not ready when planning a change of the environment (or of wait_for_ready), the apply waits for it before dependent resources are created. A timeout is an error listing the components which are not ready.`,
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true, // client-side only, defaults to false
+		},
+		hcEnvRegenerateOnChangeFieldName: {
+			Description: `Regenerate the bootstrap commands when a configuration field rendered into them changes (the proxies, registries, registry secret, CA certificates, log level, operator settings, node selector, tolerations or control plane labels).
+Has no effect if bootstrap_commands_version is 0 or -1.`,
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true, // client-side only, defaults to false
+		},
+		hcEnvForceDestroyFieldName: {
+			Description: `Delete the clusters hosted in the environment when it is destroyed (their backups are kept, clusters with a backup schedule get a final backup first).
+The final_backup and delete_backups_on_destroy settings of the cluster resources are not applied, as the environment has no access to their Terraform state.
+If not set, destroying an environment which still hosts clusters fails. Must be applied before the destroy to take effect.`,
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true, // client-side only, defaults to false
+		},
+	}
+	if asDataSource {
+		// The bootstrap commands and the readiness wait are only managed by the resource.
+		for _, k := range []string{hcEnvBootstrapCommandsFieldName, hcEnvBootstrapFieldName, hcEnvBootstrapCommandsVersionFieldName, hcEnvWaitForReadyFieldName, hcEnvRegenerateOnChangeFieldName, hcEnvForceDestroyFieldName} {
+			delete(s, k)
+		}
+	}
+	return s
+}
+
+// accountsHybridCloudEnvironmentsSchema defines the schema for a hybrid cloud environment list data-source.
+func accountsHybridCloudEnvironmentsSchema() map[string]*schema.Schema {
+	validPhases := protoEnumNames(qch.HybridCloudEnvironmentStatusPhase_name)
+	return map[string]*schema.Schema{
+		hcEnvsAccountIdFieldName: {
+			Description: fmt.Sprintf(hcEnvsFieldTemplate, "Identifier of the account"),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		hcEnvsPhaseFieldName: {
+			Description:      fmt.Sprintf(hcEnvsFieldTemplate, fmt.Sprintf("Status phase to filter the hybrid cloud environments by. Must be one of: %s", strings.Join(validPhases, ", "))),
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validPhases, false)),
+		},
+		hcEnvsHybridCloudEnvironmentsFieldName: {
+			Description: fmt.Sprintf(hcEnvsFieldTemplate, "List of hybrid cloud environments"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Description: fmt.Sprintf(hcEnvsFieldTemplate, "Individual hybrid cloud environment"),
+				Schema:      accountsHybridCloudEnvironmentSchema(true),
+			},
+		},
 	}
 }
 
+// accountsHybridCloudEnvironmentLookupSchema defines the schema for the hybrid cloud environment data-source.
+// The environment can be looked up by ID, or by name (which should match a single environment).
+func accountsHybridCloudEnvironmentLookupSchema() map[string]*schema.Schema {
+	s := accountsHybridCloudEnvironmentSchema(true)
+	lookupKeys := []string{hcEnvIdFieldName, hcEnvNameFieldName}
+	s[hcEnvIdFieldName] = &schema.Schema{
+		Description:  fmt.Sprintf(hcEnvFieldTemplate, "ID (either the id or the name should be provided)"),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: lookupKeys,
+	}
+	s[hcEnvNameFieldName] = &schema.Schema{
+		Description:  fmt.Sprintf(hcEnvFieldTemplate, "Name (used to look up the environment if no id is provided)"),
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: lookupKeys,
+	}
+	return s
+}
+
 // accountsHybridCloudEnvironmentConfigurationSchema defines the schema for the configuration of a hybrid cloud environment.
-func accountsHybridCloudEnvironmentConfigurationSchema() map[string]*schema.Schema {
+func accountsHybridCloudEnvironmentConfigurationSchema(asDataSource bool) map[string]*schema.Schema {
+	maxItems := 1
+	if asDataSource {
+		// We should not set Max Items
+		maxItems = 0
+	}
 	validLogLevels := protoEnumNames(qch.HybridCloudEnvironmentConfigurationLogLevel_name)
 	logLevel := &schema.Schema{
-		Description:      fmt.Sprintf("Log level for deployed components. Must be one of: %s.", strings.Join(validLogLevels, ", ")),
-		Type:             schema.TypeString,
-		Optional:         true,
-		Computed:         true,
-		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validLogLevels, false)),
+		Description: fmt.Sprintf("Log level for deployed components. Must be one of: %s.", strings.Join(validLogLevels, ", ")),
+		Type:        schema.TypeString,
+		Optional:    !asDataSource,
+		Computed:    true,
+	}
+	advancedOperatorSettings := &schema.Schema{
+		Description: "Advanced operator settings as a YAML string. Conflicts with `operator_settings`.",
+		Type:        schema.TypeString,
+		Optional:    !asDataSource,
+		Computed:    true,
+	}
+	caCertificates := &schema.Schema{
+		Description: "PEM encoded CA certificates for custom certificate authorities (e.g. loaded with `file()`). Validated during plan: each certificate must be a CA certificate, a warning is reported if one is expired, not valid yet or expires within 30 days.",
+		Type:        schema.TypeString,
+		Optional:    !asDataSource,
+		Computed:    true,
+	}
+	if !asDataSource {
+		caCertificates.ValidateDiagFunc = validation.ToDiagFunc(validateCACertificatesPEM)
+		logLevel.ValidateDiagFunc = validation.ToDiagFunc(validation.StringInSlice(validLogLevels, false))
+		advancedOperatorSettings.DiffSuppressFunc = suppressEquivalentYAMLDiff
+		advancedOperatorSettings.ValidateDiagFunc = validation.ToDiagFunc(validateYAML)
+		// StateFunc normalizes the YAML on save, which is good practice with DiffSuppressFunc.
+		advancedOperatorSettings.StateFunc = normalizeYAMLStateFunc
+		advancedOperatorSettings.ConflictsWith = []string{hcEnvConfigurationFieldName + ".0." + hcEnvCfgOperatorSettingsFieldName}
 	}
 
 	return map[string]*schema.Schema{
 		hcEnvCfgNamespaceFieldName: {
 			Description: "The Kubernetes namespace where the Qdrant hybrid cloud components will be deployed.",
 			Type:        schema.TypeString,
-			Required:    true,
-			ForceNew:    true,
+			Required:    !asDataSource,
+			Computed:    asDataSource,
+			ForceNew:    !asDataSource,
 		},
 		hcEnvCfgLastModifiedAtFieldName: {
 			Description: "Last modification timestamp of the configuration.",
@@ -171,19 +313,19 @@ func accountsHybridCloudEnvironmentConfigurationSchema() map[string]*schema.Sche
 		hcEnvCfgHttpProxyUrlFieldName: {
 			Description: "Optional HTTP proxy URL.",
 			Type:        schema.TypeString,
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 		},
 		hcEnvCfgHttpsProxyUrlFieldName: {
 			Description: "Optional HTTPS proxy URL.",
 			Type:        schema.TypeString,
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 		},
 		hcEnvCfgNoProxyConfigsFieldName: {
 			Description: "List of hosts that should not be proxied.",
 			Type:        schema.TypeSet, // Order isn't significant (matches the cluster allowed_ip_source_ranges pattern).
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 			Elem:        &schema.Schema{Type: schema.TypeString},
 			Set:         schema.HashString,
@@ -191,105 +333,77 @@ func accountsHybridCloudEnvironmentConfigurationSchema() map[string]*schema.Sche
 		hcEnvCfgContainerRegistryUrlFieldName: {
 			Description: "Container registry URL.",
 			Type:        schema.TypeString,
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 		},
 		hcEnvCfgChartRepositoryUrlFieldName: {
 			Description: "Chart registry URL.",
 			Type:        schema.TypeString,
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 		},
 		hcEnvCfgRegistrySecretNameFieldName: {
 			Description: "Kubernetes secret name containing registry credentials.",
 			Type:        schema.TypeString,
-			Optional:    true,
-			Computed:    true,
-		},
-		hcEnvCfgCaCertificatesFieldName: {
-			Description: "CA certificates for custom certificate authorities.",
-			Type:        schema.TypeString,
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 		},
+		hcEnvCfgCaCertificatesFieldName: caCertificates,
 		hcEnvCfgDatabaseStorageClassFieldName: {
 			Description: "Default database storage class.",
 			Type:        schema.TypeString,
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 		},
 		hcEnvCfgSnapshotStorageClassFieldName: {
 			Description: "Default snapshot storage class.",
 			Type:        schema.TypeString,
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 		},
 		hcEnvCfgVolumeSnapshotStorageClassFieldName: {
 			Description: "Default volume snapshot storage class.",
 			Type:        schema.TypeString,
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 		},
-		hcEnvCfgLogLevelFieldName: logLevel,
-		hcEnvCfgAdvancedOperatorSettingsFieldName: {
-			Description: "Advanced operator settings as a YAML string.",
-			Type:        schema.TypeString,
-			Optional:    true,
+		hcEnvCfgLogLevelFieldName:                 logLevel,
+		hcEnvCfgAdvancedOperatorSettingsFieldName: advancedOperatorSettings,
+		hcEnvCfgOperatorSettingsFieldName: {
+			Description: "Typed operator settings, merged into the advanced operator settings. Conflicts with `advanced_operator_settings`.",
+			Type:        schema.TypeList,
+			Optional:    !asDataSource,
 			Computed:    true,
-			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-				var oldData, newData interface{}
-				if err := yaml.Unmarshal([]byte(old), &oldData); err != nil {
-					return false
-				}
-				if err := yaml.Unmarshal([]byte(new), &newData); err != nil {
-					return false
-				}
-				return reflect.DeepEqual(oldData, newData)
-			},
-			ValidateDiagFunc: validation.ToDiagFunc(func(v interface{}, k string) (ws []string, es []error) {
-				if err := yaml.Unmarshal([]byte(v.(string)), new(interface{})); err != nil {
-					es = append(es, fmt.Errorf("%q contains invalid YAML: %w", k, err))
-				}
-				return
-			}),
-			// StateFunc normalizes the YAML on save, which is good practice with DiffSuppressFunc.
-			// This ensures the state file has a consistent format, even if user input varies.
-			StateFunc: func(v interface{}) string {
-				var data interface{}
-				if err := yaml.Unmarshal([]byte(v.(string)), &data); err != nil {
-					return v.(string) // On error, keep original
-				}
-				out, _ := yaml.Marshal(data)
-				return string(out)
-			},
+			MaxItems:    maxItems,
+			Elem:        &schema.Resource{Schema: accountsHybridCloudEnvironmentOperatorSettingsSchema(asDataSource)},
 		},
 		hcEnvCfgNodeSelectorFieldName: {
 			Description: "Node selector labels for scheduling control plane components.",
 			Type:        schema.TypeSet, // Order isn't significant; the backend stores these as an unordered map.
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 			Elem: &schema.Resource{
-				Schema: keyValSchema(false),
+				Schema: keyValSchema(asDataSource),
 			},
 			Set: keyValHashFunc,
 		},
 		hcEnvCfgTolerationsFieldName: {
 			Description: "Tolerations for scheduling control plane components.",
 			Type:        schema.TypeSet, // Order isn't significant.
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 			Elem: &schema.Resource{
-				Schema: tolerationSchema(false),
+				Schema: tolerationSchema(asDataSource),
 			},
 			Set: tolerationHashFunc,
 		},
 		hcEnvCfgControlPlaneLabelsFieldName: {
 			Description: "Additional labels to apply to control plane components.",
 			Type:        schema.TypeSet, // Order isn't significant; the backend stores these as an unordered map.
-			Optional:    true,
+			Optional:    !asDataSource,
 			Computed:    true,
 			Elem: &schema.Resource{
-				Schema: keyValSchema(false),
+				Schema: keyValSchema(asDataSource),
 			},
 			Set: keyValHashFunc,
 		},
@@ -479,6 +593,7 @@ func flattenHCEnv(env *qch.HybridCloudEnvironment) map[string]interface{} {
 
 	out[hcEnvConfigurationFieldName] = flattenHCEnvConfiguration(env.GetConfiguration())
 	out[hcEnvStatusFieldName] = flattenHCEnvStatus(env.GetStatus())
+	out[hcEnvCaCertificatesNotAfterFieldName], out[hcEnvCaCertificatesFingerprintsFieldName] = certificatesInfo(env.GetConfiguration().GetCaCertificates())
 
 	return out
 }
@@ -512,6 +627,7 @@ func flattenHCEnvConfiguration(cfg *qch.HybridCloudEnvironmentConfiguration) []i
 			}
 		}
 	}
+	configMap[hcEnvCfgOperatorSettingsFieldName] = flattenHCEnvOperatorSettings(cfg.GetAdvancedOperatorSettings())
 
 	if ts := cfg.GetLastModifiedAt(); ts != nil {
 		configMap[hcEnvCfgLastModifiedAtFieldName] = formatTime(ts)
@@ -614,6 +730,15 @@ func expandHCEnv(d *schema.ResourceData, defaultAccountID string) (*qch.HybridCl
 		Configuration: expandHCEnvConfiguration(d.Get(hcEnvConfigurationFieldName).([]interface{})),
 	}
 
+	// The typed operator settings replace the advanced operator settings when configured.
+	if hcEnvOperatorSettingsConfigured(d.GetRawConfig()) {
+		settings, err := expandHCEnvOperatorSettings(d.Get(hcEnvConfigurationFieldName + ".0." + hcEnvCfgOperatorSettingsFieldName).([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", hcEnvCfgOperatorSettingsFieldName, err)
+		}
+		env.Configuration.AdvancedOperatorSettings = settings
+	}
+
 	// For create, namespace is required. For update, it's optional but if provided, it must not be empty.
 	if env.Configuration.GetNamespace() == "" && d.IsNewResource() {
 		return nil, fmt.Errorf("configuration.namespace must be set for a new environment")
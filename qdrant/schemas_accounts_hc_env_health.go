@@ -0,0 +1,198 @@
+package qdrant
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+const (
+	hcEnvHealthFieldTemplate = "Hybrid cloud environment health Schema %s field"
+
+	hcEnvHealthAccountIdFieldName                = "account_id"
+	hcEnvHealthEnvironmentIdFieldName            = "hybrid_cloud_environment_id"
+	hcEnvHealthHealthyFieldName                  = "healthy"
+	hcEnvHealthReasonFieldName                   = "reason"
+	hcEnvHealthPhaseFieldName                    = "phase"
+	hcEnvHealthClusterCreationReadinessFieldName = "cluster_creation_readiness"
+	hcEnvHealthMessageFieldName                  = "message"
+	hcEnvHealthKubernetesVersionFieldName        = "kubernetes_version"
+	hcEnvHealthKubernetesDistributionFieldName   = "kubernetes_distribution"
+	hcEnvHealthNumberOfNodesFieldName            = "number_of_nodes"
+	hcEnvHealthLastModifiedAtFieldName           = "last_modified_at"
+	hcEnvHealthComponentsFieldName               = "components"
+	hcEnvHealthUnhealthyComponentsFieldName      = "unhealthy_components"
+
+	hcEnvHealthComponentNameFieldName      = "name"
+	hcEnvHealthComponentNamespaceFieldName = "namespace"
+	hcEnvHealthComponentVersionFieldName   = "version"
+	hcEnvHealthComponentPhaseFieldName     = "phase"
+	hcEnvHealthComponentMessageFieldName   = "message"
+	hcEnvHealthComponentHealthyFieldName   = "healthy"
+)
+
+// accountsHybridCloudEnvironmentHealthSchema defines the schema for the hybrid cloud environment health data-source.
+func accountsHybridCloudEnvironmentHealthSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		hcEnvHealthAccountIdFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Identifier of the account"),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		hcEnvHealthEnvironmentIdFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Identifier of the hybrid cloud environment"),
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		hcEnvHealthHealthyFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Whether the environment is ready, allows the creation of clusters and all its components are ready"),
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		hcEnvHealthReasonFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Why the environment isn't healthy (empty if healthy), e.g. for the error message of a check block"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthPhaseFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Phase of the environment"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthClusterCreationReadinessFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Whether clusters can be created in the environment"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthMessageFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Status message of the environment"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthKubernetesVersionFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Kubernetes version of the cluster hosting the environment"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthKubernetesDistributionFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Kubernetes distribution of the cluster hosting the environment"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthNumberOfNodesFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Number of Kubernetes nodes"),
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		hcEnvHealthLastModifiedAtFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Last modification timestamp of the status"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthComponentsFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Status of the components deployed in the environment"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: accountsHybridCloudEnvironmentHealthComponentSchema(),
+			},
+		},
+		hcEnvHealthUnhealthyComponentsFieldName: {
+			Description: fmt.Sprintf(hcEnvHealthFieldTemplate, "Names of the components which are not ready (sorted)"),
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+// accountsHybridCloudEnvironmentHealthComponentSchema defines the schema of a component in the health data-source.
+func accountsHybridCloudEnvironmentHealthComponentSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		hcEnvHealthComponentNameFieldName: {
+			Description: "Name of the component.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthComponentNamespaceFieldName: {
+			Description: "Kubernetes namespace of the component.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthComponentVersionFieldName: {
+			Description: "Version of the component.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthComponentPhaseFieldName: {
+			Description: "Phase of the component.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthComponentMessageFieldName: {
+			Description: "Status message of the component.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		hcEnvHealthComponentHealthyFieldName: {
+			Description: "Whether the component is ready.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+}
+
+// isHCEnvComponentReady returns true if the component is ready.
+func isHCEnvComponentReady(c *qch.HybridCloudEnvironmentComponentStatus) bool {
+	return c.GetPhase() == qch.HybridCloudEnvironmentComponentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_READY
+}
+
+// flattenHCEnvHealth maps the status of the hybrid cloud environment to the health data-source fields.
+// The environment is healthy if it is ready, allows the creation of clusters and all its components are ready.
+func flattenHCEnvHealth(env *qch.HybridCloudEnvironment) map[string]interface{} {
+	st := env.GetStatus()
+	components := make([]interface{}, 0, len(st.GetComponentStatuses()))
+	unhealthy := []string{}
+	for _, c := range st.GetComponentStatuses() {
+		ready := isHCEnvComponentReady(c)
+		if !ready {
+			unhealthy = append(unhealthy, c.GetName())
+		}
+		components = append(components, map[string]interface{}{
+			hcEnvHealthComponentNameFieldName:      c.GetName(),
+			hcEnvHealthComponentNamespaceFieldName: c.GetNamespace(),
+			hcEnvHealthComponentVersionFieldName:   c.GetVersion(),
+			hcEnvHealthComponentPhaseFieldName:     c.GetPhase().String(),
+			hcEnvHealthComponentMessageFieldName:   c.GetMessage(),
+			hcEnvHealthComponentHealthyFieldName:   ready,
+		})
+	}
+	sort.Strings(unhealthy)
+
+	healthy := isHCEnvReady(env) && len(unhealthy) == 0
+	reason := ""
+	if !healthy {
+		reason = describeHCEnvNotReady(env)
+	}
+	out := map[string]interface{}{
+		hcEnvHealthHealthyFieldName:                  healthy,
+		hcEnvHealthReasonFieldName:                   reason,
+		hcEnvHealthPhaseFieldName:                    st.GetPhase().String(),
+		hcEnvHealthClusterCreationReadinessFieldName: st.GetClusterCreationReadiness().String(),
+		hcEnvHealthMessageFieldName:                  st.GetMessage(),
+		hcEnvHealthKubernetesVersionFieldName:        st.GetKubernetesVersion(),
+		hcEnvHealthKubernetesDistributionFieldName:   st.GetKubernetesDistribution().String(),
+		hcEnvHealthNumberOfNodesFieldName:            int(st.GetNumberOfNodes()),
+		hcEnvHealthLastModifiedAtFieldName:           "",
+		hcEnvHealthComponentsFieldName:               components,
+		hcEnvHealthUnhealthyComponentsFieldName:      unhealthy,
+	}
+	if ts := st.GetLastModifiedAt(); ts != nil {
+		out[hcEnvHealthLastModifiedAtFieldName] = formatTime(ts)
+	}
+	return out
+}
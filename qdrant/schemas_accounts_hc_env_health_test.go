@@ -0,0 +1,78 @@
+package qdrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	qch "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/hybrid/v1"
+)
+
+func TestFlattenHCEnvHealth_Healthy(t *testing.T) {
+	env := newReadyHCEnv()
+	env.Status.KubernetesVersion = "v1.31.2"
+	env.Status.NumberOfNodes = 3
+	env.Status.LastModifiedAt = timestamppb.New(time.Date(2025, 9, 16, 9, 41, 8, 0, time.UTC))
+	env.Status.ComponentStatuses = []*qch.HybridCloudEnvironmentComponentStatus{
+		{Name: "qdrant-operator", Version: "2.1.0", Namespace: "qdrant-hc", Phase: qch.HybridCloudEnvironmentComponentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_READY},
+	}
+
+	got := flattenHCEnvHealth(env)
+	assert.Equal(t, true, got[hcEnvHealthHealthyFieldName])
+	assert.Equal(t, "", got[hcEnvHealthReasonFieldName])
+	assert.Equal(t, "v1.31.2", got[hcEnvHealthKubernetesVersionFieldName])
+	assert.Equal(t, 3, got[hcEnvHealthNumberOfNodesFieldName])
+	assert.Equal(t, "2025-09-16T09:41:08Z", got[hcEnvHealthLastModifiedAtFieldName])
+	assert.Equal(t, []string{}, got[hcEnvHealthUnhealthyComponentsFieldName])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			hcEnvHealthComponentNameFieldName:      "qdrant-operator",
+			hcEnvHealthComponentNamespaceFieldName: "qdrant-hc",
+			hcEnvHealthComponentVersionFieldName:   "2.1.0",
+			hcEnvHealthComponentPhaseFieldName:     "HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_READY",
+			hcEnvHealthComponentMessageFieldName:   "",
+			hcEnvHealthComponentHealthyFieldName:   true,
+		},
+	}, got[hcEnvHealthComponentsFieldName])
+}
+
+func TestFlattenHCEnvHealth_NotReady(t *testing.T) {
+	got := flattenHCEnvHealth(newNotReadyHCEnv())
+	assert.Equal(t, false, got[hcEnvHealthHealthyFieldName])
+	assert.Equal(t, []string{"prometheus", "qdrant-operator"}, got[hcEnvHealthUnhealthyComponentsFieldName])
+	assert.Contains(t, got[hcEnvHealthReasonFieldName], "ImagePullBackOff")
+	assert.Equal(t, "operator not ready", got[hcEnvHealthMessageFieldName])
+	assert.Len(t, got[hcEnvHealthComponentsFieldName], 3)
+}
+
+func TestFlattenHCEnvHealth_ReadyWithFailingComponent(t *testing.T) {
+	env := newReadyHCEnv()
+	env.Status.ComponentStatuses = []*qch.HybridCloudEnvironmentComponentStatus{
+		{Name: "prometheus", Phase: qch.HybridCloudEnvironmentComponentStatusPhase_HYBRID_CLOUD_ENVIRONMENT_COMPONENT_STATUS_PHASE_FAILED, Message: "CrashLoopBackOff"},
+	}
+	got := flattenHCEnvHealth(env)
+	assert.Equal(t, false, got[hcEnvHealthHealthyFieldName])
+	assert.Contains(t, got[hcEnvHealthReasonFieldName], "prometheus")
+}
+
+func TestFlattenHCEnvHealth_NoStatus(t *testing.T) {
+	got := flattenHCEnvHealth(&qch.HybridCloudEnvironment{Id: "env-1"})
+	assert.Equal(t, false, got[hcEnvHealthHealthyFieldName])
+	assert.Contains(t, got[hcEnvHealthReasonFieldName], "no status reported yet")
+	assert.Equal(t, []interface{}{}, got[hcEnvHealthComponentsFieldName])
+}
+
+func TestFlattenHCEnvHealth_MatchesSchema(t *testing.T) {
+	s := accountsHybridCloudEnvironmentHealthSchema()
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{hcEnvHealthEnvironmentIdFieldName: "env-1"})
+	for k, v := range flattenHCEnvHealth(newNotReadyHCEnv()) {
+		require.Contains(t, s, k)
+		require.NoError(t, d.Set(k, v), k)
+	}
+	assert.Equal(t, "qdrant-operator", d.Get(hcEnvHealthComponentsFieldName+".0."+hcEnvHealthComponentNameFieldName))
+	assert.Contains(t, Provider().DataSourcesMap, "qdrant-cloud_accounts_hybrid_cloud_environment_health")
+}
@@ -18,7 +18,7 @@ import (
 )
 
 func TestAccountsHybridCloudEnvironmentConfigurationSchema(t *testing.T) {
-	configuration := accountsHybridCloudEnvironmentConfigurationSchema()
+	configuration := accountsHybridCloudEnvironmentConfigurationSchema(false)
 
 	t.Run(hcEnvCfgLogLevelFieldName, func(t *testing.T) {
 		testGeneratedEnumValidation(t, hcEnvCfgLogLevelFieldName, configuration[hcEnvCfgLogLevelFieldName], nil, qch.HybridCloudEnvironmentConfigurationLogLevel_name)
@@ -108,6 +108,15 @@ func TestFlattenHCEnv(t *testing.T) {
 					require.NoError(t, err)
 					return string(b)
 				}(),
+				hcEnvCfgOperatorSettingsFieldName: []interface{}{
+					map[string]interface{}{
+						hcEnvOpFeatureFlagsFieldName:         map[string]interface{}{},
+						hcEnvOpReconcileConcurrencyFieldName: 0,
+						hcEnvOpLogFormatFieldName:            "",
+						hcEnvOpResourcesFieldName:            []interface{}{},
+						hcEnvOpExtraYamlFieldName:            "key: value\nnested:\n  num: 1.0\n",
+					},
+				},
 				hcEnvCfgNodeSelectorFieldName: []interface{}{
 					map[string]interface{}{"key": "key1", "value": "value1"},
 				},
@@ -125,6 +134,9 @@ func TestFlattenHCEnv(t *testing.T) {
 			},
 		},
 		hcEnvStatusFieldName: []interface{}{},
+		// ca-cert-data isn't PEM encoded
+		hcEnvCaCertificatesNotAfterFieldName:     "",
+		hcEnvCaCertificatesFingerprintsFieldName: []string{},
 	}
 
 	assert.Equal(t, want, got)
@@ -168,7 +180,7 @@ func TestExpandHCEnvForCreate_UsesDefaultAccountID(t *testing.T) {
 		},
 	}
 
-	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(), map[string]interface{}{
+	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(false), map[string]interface{}{
 		hcEnvNameFieldName:          "local-test-new",
 		hcEnvConfigurationFieldName: []interface{}{configMap},
 	})
@@ -201,7 +213,7 @@ func TestExpandHCEnvForCreate_UsesDefaultAccountID(t *testing.T) {
 }
 
 func TestExpandHCEnvForCreate_MissingNamespaceErrors(t *testing.T) {
-	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(), map[string]interface{}{
+	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(false), map[string]interface{}{
 		hcEnvNameFieldName:          "local-test-new",
 		hcEnvConfigurationFieldName: []interface{}{map[string]interface{}{}}, // no namespace
 	})
@@ -215,7 +227,7 @@ func TestExpandHCEnvForUpdate_WithOverrideAccountID(t *testing.T) {
 	configMap := map[string]interface{}{
 		hcEnvCfgNamespaceFieldName: "qdrant-hc-update",
 	}
-	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(), nil)
+	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(false), nil)
 	d.SetId("00000000-0000-0000-0000-0000000000AA") // This makes HasChange work
 
 	// Set the new values
@@ -234,7 +246,7 @@ func TestExpandHCEnvForUpdate_WithOverrideAccountID(t *testing.T) {
 }
 
 func TestExpandHCEnvForUpdate_NoChanges(t *testing.T) {
-	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(), map[string]interface{}{})
+	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(false), map[string]interface{}{})
 	d.SetId("00000000-0000-0000-0000-0000000000BB")
 
 	env, err := expandHCEnv(d, "default-account")
@@ -246,7 +258,7 @@ func TestExpandHCEnvForUpdate_NoChanges(t *testing.T) {
 }
 
 func TestSchema_BootstrapVersionFlags(t *testing.T) {
-	s := accountsHybridCloudEnvironmentSchema()
+	s := accountsHybridCloudEnvironmentSchema(false)
 	field := s[hcEnvBootstrapCommandsVersionFieldName]
 	require.NotNil(t, field)
 
@@ -256,7 +268,7 @@ func TestSchema_BootstrapVersionFlags(t *testing.T) {
 }
 
 func TestSchema_ConfigurationNamespaceForceNew(t *testing.T) {
-	cfg := accountsHybridCloudEnvironmentConfigurationSchema()
+	cfg := accountsHybridCloudEnvironmentConfigurationSchema(false)
 	ns := cfg[hcEnvCfgNamespaceFieldName]
 	require.NotNil(t, ns)
 	assert.True(t, ns.ForceNew, "configuration.namespace must be ForceNew")
@@ -352,8 +364,8 @@ func TestConvertMapKeysToStrings(t *testing.T) {
 // perpetually diff (CP-552).
 func TestHCEnvOptionalFieldsMustBeComputed(t *testing.T) {
 	schemas := map[string]map[string]*schema.Schema{
-		"hybridCloudEnvironment":              accountsHybridCloudEnvironmentSchema(),
-		"hybridCloudEnvironmentConfiguration": accountsHybridCloudEnvironmentConfigurationSchema(),
+		"hybridCloudEnvironment":              accountsHybridCloudEnvironmentSchema(false),
+		"hybridCloudEnvironmentConfiguration": accountsHybridCloudEnvironmentConfigurationSchema(false),
 	}
 	for schemaName, schemaMap := range schemas {
 		for fieldName, fieldSchema := range schemaMap {
@@ -373,7 +385,7 @@ func TestHCEnvOptionalFieldsMustBeComputed(t *testing.T) {
 // must be TypeSet, because the backend returns them in a non-deterministic order
 // and TypeList would report a forever-diff (CP-552; cf. #181).
 func TestHCEnvUnorderedCollectionsAreSets(t *testing.T) {
-	cfg := accountsHybridCloudEnvironmentConfigurationSchema()
+	cfg := accountsHybridCloudEnvironmentConfigurationSchema(false)
 	for _, field := range []string{
 		hcEnvCfgNodeSelectorFieldName,
 		hcEnvCfgTolerationsFieldName,
@@ -394,7 +406,7 @@ func TestHCEnvUnorderedCollectionsAreSets(t *testing.T) {
 // carry the same explicit Set hash funcs as the equivalent cluster fields, so the
 // set membership (not ordering) drives equality.
 func TestHCEnvSetFieldsConfigured(t *testing.T) {
-	cfg := accountsHybridCloudEnvironmentConfigurationSchema()
+	cfg := accountsHybridCloudEnvironmentConfigurationSchema(false)
 	for _, f := range []string{
 		hcEnvCfgNodeSelectorFieldName,
 		hcEnvCfgTolerationsFieldName,
@@ -415,7 +427,7 @@ func TestHCEnvSetFieldsConfigured(t *testing.T) {
 // NOT produce a diff: two sets built from the same elements in opposite order
 // must be equal under the field's Set func.
 func TestHCEnvUnorderedCollectionsOrderIndependent(t *testing.T) {
-	cfg := accountsHybridCloudEnvironmentConfigurationSchema()
+	cfg := accountsHybridCloudEnvironmentConfigurationSchema(false)
 
 	kv := []interface{}{
 		map[string]interface{}{"key": "node_pool", "value": "qdrant-hybrid-cloud"},
@@ -481,7 +493,7 @@ func TestHCEnvConfigFlattenExpandRoundTrip(t *testing.T) {
 	flat := flattenHCEnvConfiguration(orig)
 	// Build state the same way the Read function does (d.Set), which accepts the
 	// []string the flatten emits for set-of-string fields like no_proxy_configs.
-	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(), nil)
+	d := schema.TestResourceDataRaw(t, accountsHybridCloudEnvironmentSchema(false), nil)
 	require.NoError(t, d.Set(hcEnvNameFieldName, "rt"))
 	require.NoError(t, d.Set(hcEnvConfigurationFieldName, flat))
 	got := expandHCEnvConfiguration(d.Get(hcEnvConfigurationFieldName).([]interface{}))
@@ -0,0 +1,90 @@
+package qdrant
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	authv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/auth/v1"
+)
+
+const (
+	managementKeyFieldTemplate        = "Management Key Schema %s field"
+	managementKeyIDFieldName          = "id"
+	managementKeyAccountIDFieldName   = "account_id"
+	managementKeyCreatedAtFieldName   = "created_at"
+	managementKeyPrefixFieldName      = "prefix"
+	managementKeyKeyFieldName         = "key"
+	managementKeyRotateAfterFieldName = "rotate_after"
+	managementKeyRotationDueFieldName = "rotation_due"
+)
+
+// accountsManagementKeySchema returns the schema for the management key resource.
+func accountsManagementKeySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		managementKeyIDFieldName: {
+			Description: fmt.Sprintf(managementKeyFieldTemplate, "Management Key Identifier"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		managementKeyAccountIDFieldName: {
+			Description: fmt.Sprintf(managementKeyFieldTemplate, "Account Identifier"),
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+		},
+		managementKeyCreatedAtFieldName: {
+			Description: fmt.Sprintf(managementKeyFieldTemplate, "Timestamp when the Management Key is created"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		managementKeyPrefixFieldName: {
+			Description: fmt.Sprintf(managementKeyFieldTemplate, "Prefix of the Management Key (to identify it)"),
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		managementKeyKeyFieldName: {
+			Description: fmt.Sprintf(managementKeyFieldTemplate, "Management Key (only available after creation, not after import)"),
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+		},
+		managementKeyRotateAfterFieldName: {
+			Description: fmt.Sprintf(managementKeyFieldTemplate, `Timestamp (RFC3339) after which the Management Key should be rotated, e.g. the rotation_rfc3339 of a time_rotating resource.
+Client-side only rotation trigger: management keys don't expire in Qdrant Cloud, the key stays valid after this time. It must be in the future when set, and changing it replaces (rotates) the key`),
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+		},
+		managementKeyRotationDueFieldName: {
+			Description: fmt.Sprintf(managementKeyFieldTemplate, "Whether rotate_after has passed (when last read), a warning is reported as well"),
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+	}
+}
+
+// flattenManagementKey flattens the management key response into a map[string]interface{}.
+// keyAvailable: The key itself is only returned on creation.
+func flattenManagementKey(key *authv1.ManagementKey, keyAvailable bool) map[string]interface{} {
+	data := map[string]interface{}{
+		managementKeyIDFieldName:        key.GetId(),
+		managementKeyAccountIDFieldName: key.GetAccountId(),
+		managementKeyCreatedAtFieldName: formatTime(key.GetCreatedAt()),
+		managementKeyPrefixFieldName:    key.GetPrefix(),
+	}
+	if keyAvailable {
+		data[managementKeyKeyFieldName] = key.GetKey()
+	}
+	return data
+}
+
+// managementKeyRotationDue returns true if the provided rotation time (RFC3339, empty if none) has passed at the provided time.
+func managementKeyRotationDue(rotateAfter string, now time.Time) bool {
+	ts := parseTime(rotateAfter)
+	return ts != nil && !now.Before(ts.AsTime())
+}
@@ -0,0 +1,52 @@
+package qdrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/auth/v1"
+)
+
+func TestFlattenManagementKey(t *testing.T) {
+	createdAt := timestamppb.New(time.Date(2025, 9, 16, 9, 41, 5, 0, time.UTC))
+	key := &authv1.ManagementKey{
+		Id:        "key-id-1",
+		AccountId: "account-id-1",
+		CreatedAt: createdAt,
+		Prefix:    "abc12",
+		Key:       "abc12-secret",
+	}
+	expected := map[string]interface{}{
+		managementKeyIDFieldName:        "key-id-1",
+		managementKeyAccountIDFieldName: "account-id-1",
+		managementKeyCreatedAtFieldName: "2025-09-16T09:41:05Z",
+		managementKeyPrefixFieldName:    "abc12",
+	}
+	assert.Equal(t, expected, flattenManagementKey(key, false))
+
+	expected[managementKeyKeyFieldName] = "abc12-secret"
+	assert.Equal(t, expected, flattenManagementKey(key, true))
+}
+
+func TestManagementKeyRotationDue(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, managementKeyRotationDue("", now))
+	assert.False(t, managementKeyRotationDue("invalid", now))
+	assert.False(t, managementKeyRotationDue("2026-01-02T00:00:00Z", now))
+	assert.True(t, managementKeyRotationDue("2026-01-01T00:00:00Z", now))
+	assert.True(t, managementKeyRotationDue("2025-12-31T00:00:00+01:00", now))
+}
+
+func TestManagementKeySchema(t *testing.T) {
+	s := accountsManagementKeySchema()
+	assert.True(t, s[managementKeyKeyFieldName].Sensitive)
+	assert.True(t, s[managementKeyRotateAfterFieldName].ForceNew)
+	assert.True(t, s[managementKeyAccountIDFieldName].ForceNew)
+	for _, k := range []string{managementKeyIDFieldName, managementKeyCreatedAtFieldName, managementKeyPrefixFieldName, managementKeyRotationDueFieldName} {
+		assert.True(t, s[k].Computed, k)
+		assert.False(t, s[k].Optional, k)
+	}
+}
@@ -0,0 +1,229 @@
+package qdrant
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+// Constant keys and descriptions for the package selector fields.
+const (
+	// Field keys.
+	fieldMinRam  = "min_ram"
+	fieldMinCpu  = "min_cpu"
+	fieldMinDisk = "min_disk"
+	fieldGpu     = "gpu"
+
+	// Descriptions.
+	descriptionMinRam            = "The minimum amount of RAM of the package (e.g., '8Gi' or '8GiB')"
+	descriptionMinCpu            = "The minimum amount of CPU of the package (e.g., '2000m' or '2')"
+	descriptionMinDisk           = "The minimum amount of disk of the package (e.g., '64Gi' or '64GiB')"
+	descriptionGpu               = "Whether the package should have a GPU (true) or not (false), if omitted both are considered"
+	descriptionTierFilter        = "The tier of the package (if omitted all tiers are considered)"
+	descriptionMultiAzFilter     = "Whether the package should support multi-availability zone deployment (if omitted both are considered)"
+	descriptionStorageTierFilter = "The storage performance tier the package should offer (if omitted all packages are considered)"
+)
+
+// resourceQuantitySuffixes contains the multipliers of the supported quantity suffixes (Kubernetes style),
+// the "B" variants (e.g. "GiB") are accepted as well.
+var resourceQuantitySuffixes = map[string]float64{
+	"":   1,
+	"m":  1e-3,
+	"k":  1e3,
+	"K":  1e3,
+	"M":  1e6,
+	"G":  1e9,
+	"T":  1e12,
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+}
+
+// bookingPackageRequirements contains the requirements a package should meet to be selected.
+type bookingPackageRequirements struct {
+	MinRam          float64
+	MinCpu          float64
+	MinDisk         float64
+	Gpu             *bool
+	Tier            string
+	MultiAz         *bool
+	StorageTierType string
+}
+
+// packageSelectorSchema defines the schema structure for the package selector data source.
+// It contains the requirements and the fields of the selected package.
+func packageSelectorSchema() map[string]*schema.Schema {
+	s := packageSchema()
+	// The ID is set as the ID of the data source
+	delete(s, fieldID)
+	s[clusterCloudProviderFieldName] = &schema.Schema{
+		Description: fmt.Sprintf(clusterFieldTemplate, "Cloud provider where the cluster resides"),
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	s[clusterCloudRegionFieldName] = &schema.Schema{
+		Description: fmt.Sprintf(clusterFieldTemplate, "Cloud region where the cluster resides"),
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+	s[fieldMinRam] = &schema.Schema{
+		Description:      descriptionMinRam,
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validateResourceQuantity),
+	}
+	s[fieldMinCpu] = &schema.Schema{
+		Description:      descriptionMinCpu,
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validateResourceQuantity),
+	}
+	s[fieldMinDisk] = &schema.Schema{
+		Description:      descriptionMinDisk,
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validateResourceQuantity),
+	}
+	s[fieldGpu] = &schema.Schema{
+		Description: descriptionGpu,
+		Type:        schema.TypeBool,
+		Optional:    true,
+	}
+	validTiers := protoEnumNames(qcBooking.PackageTier_name)
+	s[fieldTier] = &schema.Schema{
+		Description:      fmt.Sprintf("%s. Must be one of: %s.", descriptionTierFilter, strings.Join(validTiers, ", ")),
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validTiers, false)),
+	}
+	s[fieldMultiAz] = &schema.Schema{
+		Description: descriptionMultiAzFilter,
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+	}
+	validStorageTiers := protoEnumNames(commonv1.StorageTierType_name)
+	s[fieldStorageTierType] = &schema.Schema{
+		Description:      fmt.Sprintf("%s. Must be one of: %s.", descriptionStorageTierFilter, strings.Join(validStorageTiers, ", ")),
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(validStorageTiers, false)),
+	}
+	return s
+}
+
+// parseResourceQuantity parses a resource quantity (e.g. "8Gi", "8GiB", "2000m" or "2") into its base unit.
+// An empty string is parsed as zero.
+func parseResourceQuantity(v string) (float64, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
+	}
+	i := strings.IndexFunc(v, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(v)
+	}
+	amount, err := strconv.ParseFloat(v[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", v)
+	}
+	suffix := strings.TrimSpace(v[i:])
+	multiplier, ok := resourceQuantitySuffixes[suffix]
+	if !ok && strings.HasSuffix(suffix, "B") {
+		multiplier, ok = resourceQuantitySuffixes[strings.TrimSuffix(suffix, "B")]
+	}
+	if !ok {
+		return 0, fmt.Errorf("invalid unit %q in quantity %q", suffix, v)
+	}
+	return amount * multiplier, nil
+}
+
+// validateResourceQuantity is a SchemaValidateFunc that ensures the provided value is a valid resource quantity.
+func validateResourceQuantity(v interface{}, k string) (ws []string, es []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a string", k)}
+	}
+	if _, err := parseResourceQuantity(s); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// packageMeetsRequirements returns true if the provided (active) package meets all requirements.
+func packageMeetsRequirements(p *qcBooking.Package, req bookingPackageRequirements) bool {
+	if p.GetStatus() != qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE {
+		return false
+	}
+	if req.Tier != "" && p.GetTier().String() != req.Tier {
+		return false
+	}
+	if req.MultiAz != nil && p.GetMultiAz() != *req.MultiAz {
+		return false
+	}
+	rc := p.GetResourceConfiguration()
+	for _, c := range []struct {
+		value string
+		min   float64
+	}{
+		{rc.GetRam(), req.MinRam},
+		{rc.GetCpu(), req.MinCpu},
+		{rc.GetDisk(), req.MinDisk},
+	} {
+		amount, err := parseResourceQuantity(c.value)
+		if err != nil || amount < c.min {
+			return false
+		}
+	}
+	if req.Gpu != nil {
+		gpu, err := parseResourceQuantity(rc.GetGpu())
+		if err != nil || (gpu > 0) != *req.Gpu {
+			return false
+		}
+	}
+	if req.StorageTierType != "" {
+		found := false
+		for _, c := range p.GetAvailableStorageTierConfigurations() {
+			if c.GetStorageTierType().String() == req.StorageTierType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// selectCheapestPackage returns the cheapest package (by unit price per hour) meeting the requirements.
+// Packages with the same price are ordered by name, so the result is stable.
+func selectCheapestPackage(packages []*qcBooking.Package, req bookingPackageRequirements) (*qcBooking.Package, error) {
+	var candidates []*qcBooking.Package
+	for _, p := range packages {
+		if packageMeetsRequirements(p, req) {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("none of the %d available packages meets the requirements", len(packages))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].GetUnitIntPricePerHour() != candidates[j].GetUnitIntPricePerHour() {
+			return candidates[i].GetUnitIntPricePerHour() < candidates[j].GetUnitIntPricePerHour()
+		}
+		return candidates[i].GetName() < candidates[j].GetName()
+	})
+	return candidates[0], nil
+}
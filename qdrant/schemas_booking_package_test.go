@@ -0,0 +1,139 @@
+package qdrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qcBooking "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/booking/v1"
+	commonv1 "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/common/v1"
+)
+
+func TestParseResourceQuantity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{input: "", expected: 0},
+		{input: "2", expected: 2},
+		{input: "2000m", expected: 2},
+		{input: "500m", expected: 0.5},
+		{input: "8Gi", expected: 8 << 30},
+		{input: "8GiB", expected: 8 << 30},
+		{input: "512Mi", expected: 512 << 20},
+		{input: "1.5Gi", expected: 1.5 * (1 << 30)},
+		{input: "10G", expected: 10e9},
+		{input: "10GB", expected: 10e9},
+		{input: " 4Gi ", expected: 4 << 30},
+		{input: "Gi", wantErr: true},
+		{input: "8Xi", wantErr: true},
+		{input: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseResourceQuantity(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, got, 1e-9)
+		})
+	}
+}
+
+func TestValidateResourceQuantity(t *testing.T) {
+	_, errs := validateResourceQuantity("8GiB", "min_ram")
+	assert.Empty(t, errs)
+	_, errs = validateResourceQuantity("eight", "min_ram")
+	assert.NotEmpty(t, errs)
+	_, errs = validateResourceQuantity(8, "min_ram")
+	assert.NotEmpty(t, errs)
+}
+
+func TestSelectCheapestPackage(t *testing.T) {
+	gpu := "1000m"
+	packages := []*qcBooking.Package{
+		{
+			Id:                    "small",
+			Name:                  "small",
+			UnitIntPricePerHour:   10,
+			Status:                qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE,
+			Tier:                  qcBooking.PackageTier_PACKAGE_TIER_STANDARD,
+			ResourceConfiguration: &qcBooking.ResourceConfiguration{Ram: "4Gi", Cpu: "1000m", Disk: "32Gi"},
+		},
+		{
+			Id:                    "medium",
+			Name:                  "medium",
+			UnitIntPricePerHour:   20,
+			Status:                qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE,
+			Tier:                  qcBooking.PackageTier_PACKAGE_TIER_STANDARD,
+			ResourceConfiguration: &qcBooking.ResourceConfiguration{Ram: "8Gi", Cpu: "2000m", Disk: "64Gi"},
+			AvailableStorageTierConfigurations: []*qcBooking.AvailableStoragePerformanceTierConfigurations{
+				{StorageTierType: commonv1.StorageTierType_STORAGE_TIER_TYPE_BALANCED, PricePerHour: 1},
+			},
+		},
+		{
+			Id:                    "medium-deactivated",
+			Name:                  "medium-deactivated",
+			UnitIntPricePerHour:   5,
+			Status:                qcBooking.PackageStatus_PACKAGE_STATUS_DEACTIVATED,
+			Tier:                  qcBooking.PackageTier_PACKAGE_TIER_STANDARD,
+			ResourceConfiguration: &qcBooking.ResourceConfiguration{Ram: "8Gi", Cpu: "2000m", Disk: "64Gi"},
+		},
+		{
+			Id:                    "medium-premium",
+			Name:                  "medium-premium",
+			UnitIntPricePerHour:   30,
+			Status:                qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE,
+			Tier:                  qcBooking.PackageTier_PACKAGE_TIER_PREMIUM,
+			MultiAz:               true,
+			ResourceConfiguration: &qcBooking.ResourceConfiguration{Ram: "8Gi", Cpu: "2000m", Disk: "64Gi"},
+		},
+		{
+			Id:                    "medium-gpu",
+			Name:                  "medium-gpu",
+			UnitIntPricePerHour:   40,
+			Status:                qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE,
+			Tier:                  qcBooking.PackageTier_PACKAGE_TIER_STANDARD,
+			ResourceConfiguration: &qcBooking.ResourceConfiguration{Ram: "8Gi", Cpu: "2000m", Disk: "64Gi", Gpu: &gpu},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		req      bookingPackageRequirements
+		expected string
+	}{
+		{name: "no requirements", req: bookingPackageRequirements{}, expected: "small"},
+		{name: "min ram", req: bookingPackageRequirements{MinRam: 6 << 30}, expected: "medium"},
+		{name: "min cpu and disk", req: bookingPackageRequirements{MinCpu: 2, MinDisk: 64 << 30}, expected: "medium"},
+		{name: "gpu", req: bookingPackageRequirements{Gpu: newPointer(true)}, expected: "medium-gpu"},
+		{name: "no gpu", req: bookingPackageRequirements{MinRam: 8 << 30, Gpu: newPointer(false)}, expected: "medium"},
+		{name: "tier", req: bookingPackageRequirements{Tier: "PACKAGE_TIER_PREMIUM"}, expected: "medium-premium"},
+		{name: "multi az", req: bookingPackageRequirements{MultiAz: newPointer(true)}, expected: "medium-premium"},
+		{name: "storage tier", req: bookingPackageRequirements{StorageTierType: "STORAGE_TIER_TYPE_BALANCED"}, expected: "medium"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectCheapestPackage(packages, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got.GetId())
+		})
+	}
+
+	_, err := selectCheapestPackage(packages, bookingPackageRequirements{MinRam: 64 << 30})
+	assert.ErrorContains(t, err, "none of the 5 available packages meets the requirements")
+}
+
+func TestSelectCheapestPackageStableOnEqualPrice(t *testing.T) {
+	packages := []*qcBooking.Package{
+		{Id: "b", Name: "b", UnitIntPricePerHour: 10, Status: qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE},
+		{Id: "a", Name: "a", UnitIntPricePerHour: 10, Status: qcBooking.PackageStatus_PACKAGE_STATUS_ACTIVE},
+	}
+	got, err := selectCheapestPackage(packages, bookingPackageRequirements{})
+	require.NoError(t, err)
+	assert.Equal(t, "a", got.GetId())
+}
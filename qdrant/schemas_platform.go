@@ -0,0 +1,153 @@
+package qdrant
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	qcPlatform "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/platform/v1"
+)
+
+const (
+	cloudProvidersFieldTemplate = "Cloud Providers Schema %s field"
+	cloudRegionsFieldTemplate   = "Cloud Provider Regions Schema %s field"
+
+	platformAccountIDFieldName             = "account_id"
+	platformCloudProvidersFieldName        = "cloud_providers"
+	platformRegionsFieldName               = "regions"
+	platformCloudProviderFieldName         = "cloud_provider"
+	platformIDFieldName                    = "id"
+	platformNameFieldName                  = "name"
+	platformFreeTierFieldName              = "free_tier"
+	platformAvailableFieldName             = "available"
+	platformProviderFieldName              = "provider"
+	platformCountryIsoCodeFieldName        = "country_iso_code"
+	platformGeographicalSubRegionFieldName = "geographical_sub_region"
+)
+
+// cloudProvidersSchema defines the Terraform schema for the cloud_providers data source.
+func cloudProvidersSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		platformAccountIDFieldName: {
+			Description: "The account ID (UUID). Defaults to the provider-level account_id.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		platformCloudProvidersFieldName: {
+			Description: "List of cloud providers.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					platformIDFieldName: {
+						Description: fmt.Sprintf(cloudProvidersFieldTemplate, "Identifier of the cloud provider (to be used as cloud_provider of a cluster)"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					platformNameFieldName: {
+						Description: fmt.Sprintf(cloudProvidersFieldTemplate, "Name of the cloud provider"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					platformFreeTierFieldName: {
+						Description: fmt.Sprintf(cloudProvidersFieldTemplate, "Whether the cloud provider supports free tier clusters"),
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					platformAvailableFieldName: {
+						Description: fmt.Sprintf(cloudProvidersFieldTemplate, "Whether the cloud provider is available for the account"),
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// cloudProviderRegionsSchema defines the Terraform schema for the cloud_provider_regions data source.
+func cloudProviderRegionsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		platformAccountIDFieldName: {
+			Description: "The account ID (UUID). Defaults to the provider-level account_id.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+		},
+		platformCloudProviderFieldName: {
+			Description: "The identifier of the cloud provider to list the regions for (e.g. `aws`).",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		platformRegionsFieldName: {
+			Description: "List of regions of the cloud provider. Free tier support is not reported per region by the API, see free_tier of the qdrant-cloud_cloud_providers data source (per cloud provider).",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					platformIDFieldName: {
+						Description: fmt.Sprintf(cloudRegionsFieldTemplate, "Identifier of the region (to be used as cloud_region of a cluster)"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					platformNameFieldName: {
+						Description: fmt.Sprintf(cloudRegionsFieldTemplate, "Name of the region"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					platformProviderFieldName: {
+						Description: fmt.Sprintf(cloudRegionsFieldTemplate, "Identifier of the cloud provider of the region"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					platformAvailableFieldName: {
+						Description: fmt.Sprintf(cloudRegionsFieldTemplate, "Whether the region is available for the account"),
+						Type:        schema.TypeBool,
+						Computed:    true,
+					},
+					platformCountryIsoCodeFieldName: {
+						Description: fmt.Sprintf(cloudRegionsFieldTemplate, "ISO code of the country the region resides in"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					platformGeographicalSubRegionFieldName: {
+						Description: fmt.Sprintf(cloudRegionsFieldTemplate, "Geographical sub region of the region (e.g. Western Europe)"),
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenCloudProviders converts a list of CloudProvider proto messages into a list of maps for Terraform state.
+func flattenCloudProviders(providers []*qcPlatform.CloudProvider) []interface{} {
+	result := make([]interface{}, 0, len(providers))
+	for _, p := range providers {
+		result = append(result, map[string]interface{}{
+			platformIDFieldName:        p.GetId(),
+			platformNameFieldName:      p.GetName(),
+			platformFreeTierFieldName:  p.GetFreeTier(),
+			platformAvailableFieldName: p.GetAvailable(),
+		})
+	}
+	return result
+}
+
+// flattenCloudProviderRegions converts a list of CloudProviderRegion proto messages into a list of maps for Terraform state.
+func flattenCloudProviderRegions(regions []*qcPlatform.CloudProviderRegion) []interface{} {
+	result := make([]interface{}, 0, len(regions))
+	for _, r := range regions {
+		result = append(result, map[string]interface{}{
+			platformIDFieldName:                    r.GetId(),
+			platformNameFieldName:                  r.GetName(),
+			platformProviderFieldName:              r.GetProvider(),
+			platformAvailableFieldName:             r.GetAvailable(),
+			platformCountryIsoCodeFieldName:        r.GetCountryIsoCode(),
+			platformGeographicalSubRegionFieldName: r.GetGeographicalSubRegion(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package qdrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	qcPlatform "github.com/qdrant/qdrant-cloud-public-api/gen/go/qdrant/cloud/platform/v1"
+)
+
+func TestFlattenCloudProviders(t *testing.T) {
+	providers := []*qcPlatform.CloudProvider{
+		{Id: "aws", Name: "Amazon Web Services", FreeTier: true, Available: true},
+		{Id: "azure", Name: "Microsoft Azure", FreeTier: false, Available: false},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			platformIDFieldName:        "aws",
+			platformNameFieldName:      "Amazon Web Services",
+			platformFreeTierFieldName:  true,
+			platformAvailableFieldName: true,
+		},
+		map[string]interface{}{
+			platformIDFieldName:        "azure",
+			platformNameFieldName:      "Microsoft Azure",
+			platformFreeTierFieldName:  false,
+			platformAvailableFieldName: false,
+		},
+	}
+	assert.Equal(t, expected, flattenCloudProviders(providers))
+	assert.Equal(t, []interface{}{}, flattenCloudProviders(nil))
+}
+
+func TestFlattenCloudProviderRegions(t *testing.T) {
+	regions := []*qcPlatform.CloudProviderRegion{
+		{Id: "eu-central-1", Name: "Frankfurt", Provider: "aws", Available: true, CountryIsoCode: "DE", GeographicalSubRegion: "Western Europe"},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			platformIDFieldName:                    "eu-central-1",
+			platformNameFieldName:                  "Frankfurt",
+			platformProviderFieldName:              "aws",
+			platformAvailableFieldName:             true,
+			platformCountryIsoCodeFieldName:        "DE",
+			platformGeographicalSubRegionFieldName: "Western Europe",
+		},
+	}
+	assert.Equal(t, expected, flattenCloudProviderRegions(regions))
+	assert.Equal(t, []interface{}{}, flattenCloudProviderRegions(nil))
+}
@@ -79,9 +79,14 @@ func getServiceClient[T any](
 	return client, clientCtx, nil
 }
 
+// accountIDGetter is implemented by both schema.ResourceData and schema.ResourceDiff.
+type accountIDGetter interface {
+	GetOk(key string) (interface{}, bool)
+}
+
 // getAccountUUID get the Account ID as UUID, if defined at resouce level that is used, otherwise it fallback to the default on, specified on provider level.
 // if no account ID can be found an error will be returned.
-func getAccountUUID(d *schema.ResourceData, m interface{}) (uuid.UUID, error) {
+func getAccountUUID(d accountIDGetter, m interface{}) (uuid.UUID, error) {
 	// Get The account ID as UUID from the resource data
 	if v, ok := d.GetOk("account_id"); ok {
 		id := v.(string)
@@ -134,6 +139,18 @@ func parseDuration(v string) *durationpb.Duration {
 	return durationpb.New(result)
 }
 
+// validateDuration is a SchemaValidateFunc that ensures the provided value is a valid Go duration string.
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a string", k)}
+	}
+	if _, err := time.ParseDuration(s); err != nil {
+		return nil, []error{fmt.Errorf("%s: %q is not a valid duration (e.g. \"24h\"): %w", k, s, err)}
+	}
+	return nil, nil
+}
+
 // formatDuration formats the provided proto duration into a string
 // The resulted string will be in Go duration format, so it can be parsed with parseDuration again.
 func formatDuration(d *durationpb.Duration) string {
@@ -183,6 +183,22 @@ func TestSuppressDurationDiff(t *testing.T) {
 	})
 }
 
+func TestValidateDuration(t *testing.T) {
+	t.Run("valid duration", func(t *testing.T) {
+		_, errs := validateDuration("720h", "retention_period")
+		assert.Empty(t, errs)
+	})
+	t.Run("invalid duration", func(t *testing.T) {
+		_, errs := validateDuration("7d", "retention_period")
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "retention_period")
+	})
+	t.Run("not a string", func(t *testing.T) {
+		_, errs := validateDuration(42, "retention_period")
+		assert.Len(t, errs, 1)
+	})
+}
+
 func TestDiffStringSets_AddAndDel(t *testing.T) {
 	desired := []string{"a", "b", "c"}
 	current := []string{"b", "d"}